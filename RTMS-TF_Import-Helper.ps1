@@ -46,7 +46,8 @@ function Convert-ToTerraformDataSource {
 "@
 
         foreach ($property in $InputObject.PSObject.Properties) {
-            if ($property.Name -ne "ResourceName") {
+            # Les data sources sont recherchées dans l'API, seul l'id est renseigné
+            if ($property.Name -eq "Id") {
                 $propertyName = $property.Name.ToLower()
                 $propertyValue = $property.Value
 
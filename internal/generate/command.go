@@ -0,0 +1,55 @@
+package generate
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+
+	"github.com/Bithault/terraform-provider-rtms/rtms"
+)
+
+// Command runs the generate subcommand of the provider binary with the
+// arguments that follow it on the command line.
+func Command(args []string) error {
+	flags := flag.NewFlagSet("generate", flag.ContinueOnError)
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "Usage: terraform-provider-rtms generate [options]\n\n")
+		fmt.Fprintf(flags.Output(), "Writes the Terraform configuration of every host and monitoring service of an RTMS tenant.\n\n")
+		flags.PrintDefaults()
+	}
+
+	out := flags.String("out", ".", "directory the configuration is written to")
+	authToken := flags.String("auth-token", os.Getenv("RTMS_AUTH_TOKEN"), "X-AUTH-TOKEN for API authentication (default $RTMS_AUTH_TOKEN)")
+	cloudTempleID := flags.String("cloud-temple-id", os.Getenv("RTMS_CLOUD_TEMPLE_ID"), "cloudTempleId of the tenant (default $RTMS_CLOUD_TEMPLE_ID)")
+	endpoint := flags.String("endpoint", envDefault("RTMS_ENDPOINT", rtms.DefaultEndpoint), "base URL of the RTMS API")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if *authToken == "" || *cloudTempleID == "" {
+		return errors.New("an auth token and a cloudTempleId are required, set them with -auth-token and -cloud-temple-id or RTMS_AUTH_TOKEN and RTMS_CLOUD_TEMPLE_ID")
+	}
+
+	client := rtms.NewClient(rtms.Config{
+		AuthToken:         *authToken,
+		CloudTempleID:     *cloudTempleID,
+		Endpoint:          *endpoint,
+		MaxRetries:        4,
+		RequestsPerSecond: 10,
+	})
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	return Run(ctx, client, *out)
+}
+
+func envDefault(key, defaultValue string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return defaultValue
+}
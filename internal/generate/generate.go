@@ -0,0 +1,364 @@
+// Package generate writes the Terraform configuration matching the hosts and
+// monitoring services that already exist in an RTMS tenant, so they can be
+// imported and managed by the provider.
+package generate
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/Bithault/terraform-provider-rtms/rtms"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// Files written by Run in the output directory.
+const (
+	MainFile    = "main.tf"
+	CommonFile  = "common.tf"
+	ImportsFile = "imports.tf"
+)
+
+// Run reads every host and monitoring service of the client's tenant and
+// writes their configuration to outDir: resources in MainFile, the data
+// sources they reference in CommonFile and one import block per resource in
+// ImportsFile, so the whole tenant is adopted by a single plan and apply
+// (Terraform 1.5 or later).
+func Run(ctx context.Context, client *rtms.Client, outDir string) error {
+	hosts, err := fetchHosts(ctx, client)
+	if err != nil {
+		return fmt.Errorf("listing hosts: %w", err)
+	}
+	services, err := fetchServices(ctx, client)
+	if err != nil {
+		return fmt.Errorf("listing monitoring services: %w", err)
+	}
+
+	g := newGenerator()
+	g.addDataSources(hosts, services)
+	g.addHostsAndServices(hosts, services)
+
+	if err := os.MkdirAll(outDir, 0o755); err != nil {
+		return err
+	}
+	files := map[string][]byte{
+		MainFile:    g.main.Bytes(),
+		CommonFile:  g.common.Bytes(),
+		ImportsFile: g.imports.Bytes(),
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(outDir, name), content, 0o644); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// fetchHosts returns the detailed view of every host, sorted by id. The list
+// endpoint only returns a summary of each host.
+func fetchHosts(ctx context.Context, client *rtms.Client) ([]*rtms.Host, error) {
+	list, err := client.ListHosts(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	hosts := make([]*rtms.Host, 0, len(list))
+	for _, h := range list {
+		host, err := client.GetHost(ctx, int(h.ID))
+		if err != nil {
+			return nil, fmt.Errorf("reading host %d: %w", h.ID, err)
+		}
+		hosts = append(hosts, host)
+	}
+	sort.Slice(hosts, func(i, j int) bool { return hosts[i].ID < hosts[j].ID })
+
+	return hosts, nil
+}
+
+// fetchServices returns the detailed view of every monitoring service,
+// sorted by id.
+func fetchServices(ctx context.Context, client *rtms.Client) ([]*rtms.MonitoringService, error) {
+	list, err := client.ListMonitoringServices(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	services := make([]*rtms.MonitoringService, 0, len(list))
+	for _, s := range list {
+		service, err := client.GetMonitoringService(ctx, int(s.ID))
+		if err != nil {
+			return nil, fmt.Errorf("reading monitoring service %d: %w", s.ID, err)
+		}
+		services = append(services, service)
+	}
+	sort.Slice(services, func(i, j int) bool { return services[i].ID < services[j].ID })
+
+	return services, nil
+}
+
+type generator struct {
+	main    *hclwrite.File
+	common  *hclwrite.File
+	imports *hclwrite.File
+
+	// dataNames maps a data source type and an object id to the label of
+	// the data block emitted for that object.
+	dataNames map[string]map[rtms.ID]string
+	// typologyNames maps a list of ticket catalog item ids, joined by
+	// commas, to the label of its rtms_typology data block.
+	typologyNames map[string]string
+	namers        map[string]*namer
+}
+
+func newGenerator() *generator {
+	return &generator{
+		main:          hclwrite.NewEmptyFile(),
+		common:        hclwrite.NewEmptyFile(),
+		imports:       hclwrite.NewEmptyFile(),
+		dataNames:     map[string]map[rtms.ID]string{},
+		typologyNames: map[string]string{},
+		namers:        map[string]*namer{},
+	}
+}
+
+func (g *generator) namer(blockType string) *namer {
+	if g.namers[blockType] == nil {
+		g.namers[blockType] = newNamer()
+	}
+	return g.namers[blockType]
+}
+
+// addDataSources emits one data block per appliance, plugin, template,
+// typology, team, check period and time period referenced by the hosts and
+// services.
+func (g *generator) addDataSources(hosts []*rtms.Host, services []*rtms.MonitoringService) {
+	for _, h := range hosts {
+		if h.Appliance != nil {
+			g.addData("rtms_appliance", h.Appliance.ID, h.Appliance.Name)
+		}
+	}
+	for _, s := range services {
+		if s.Appliance != nil {
+			g.addData("rtms_appliance", s.Appliance.ID, s.Appliance.Name)
+		}
+	}
+	for _, s := range services {
+		if s.Plugin != nil {
+			g.addData("rtms_plugin", s.Plugin.ID, s.Plugin.Name)
+		}
+	}
+	for _, s := range services {
+		if s.Template != nil {
+			g.addData("rtms_template", s.Template.ID, s.Template.Name)
+		}
+	}
+	for _, s := range services {
+		if len(s.TicketCatalogsItems) > 0 {
+			g.addTypology(s.TicketCatalogsItems)
+		}
+	}
+	for _, s := range services {
+		if s.ResponsibleTeam != nil {
+			g.addData("rtms_team", s.ResponsibleTeam.ID, s.ResponsibleTeam.Name)
+		}
+	}
+	for _, s := range services {
+		if s.CheckPeriod != nil {
+			g.addData("rtms_checkperiod", s.CheckPeriod.ID, s.CheckPeriod.Name)
+		}
+	}
+	for _, s := range services {
+		if s.TimePeriod != nil {
+			g.addData("rtms_timeperiod", s.TimePeriod.ID, s.TimePeriod.Name)
+		}
+	}
+}
+
+func (g *generator) addData(dataType string, id rtms.ID, name string) {
+	if g.dataNames[dataType] == nil {
+		g.dataNames[dataType] = map[rtms.ID]string{}
+	}
+	if _, ok := g.dataNames[dataType][id]; ok {
+		return
+	}
+
+	label := g.namer(dataType).unique(name)
+	g.dataNames[dataType][id] = label
+
+	block := g.common.Body().AppendNewBlock("data", []string{dataType, label})
+	block.Body().SetAttributeValue("id", cty.NumberIntVal(int64(id)))
+	g.common.Body().AppendNewline()
+}
+
+func (g *generator) addTypology(items []rtms.TicketCatalogItem) {
+	key := typologyKey(items)
+	if _, ok := g.typologyNames[key]; ok {
+		return
+	}
+
+	names := make([]string, 0, len(items))
+	ids := make([]cty.Value, 0, len(items))
+	for _, item := range items {
+		names = append(names, camelCase(item.Name))
+		ids = append(ids, cty.NumberIntVal(int64(item.ID)))
+	}
+
+	label := g.namer("rtms_typology").unique(strings.Join(names, "_"))
+	g.typologyNames[key] = label
+
+	block := g.common.Body().AppendNewBlock("data", []string{"rtms_typology", label})
+	block.Body().SetAttributeValue("id", cty.ListVal(ids))
+	g.common.Body().AppendNewline()
+}
+
+func typologyKey(items []rtms.TicketCatalogItem) string {
+	ids := make([]string, 0, len(items))
+	for _, item := range items {
+		ids = append(ids, item.ID.String())
+	}
+	return strings.Join(ids, ",")
+}
+
+// addHostsAndServices emits each host followed by its services.
+func (g *generator) addHostsAndServices(hosts []*rtms.Host, services []*rtms.MonitoringService) {
+	hostNames := map[rtms.ID]string{}
+	for _, h := range hosts {
+		hostNames[h.ID] = g.addHost(h)
+	}
+
+	for _, h := range hosts {
+		for _, s := range services {
+			if s.Host != nil && s.Host.ID == h.ID {
+				g.addService(s, hostNames[h.ID])
+			}
+		}
+	}
+}
+
+func (g *generator) addHost(h *rtms.Host) string {
+	label := g.namer("rtms_host").unique(h.Name)
+
+	block := g.main.Body().AppendNewBlock("resource", []string{"rtms_host", label})
+	body := block.Body()
+	body.SetAttributeValue("name", cty.StringVal(h.Name))
+	body.SetAttributeValue("alias", cty.StringVal(h.Alias))
+	body.SetAttributeValue("address", cty.StringVal(h.Address))
+	if h.Community != "" {
+		body.SetAttributeValue("community", cty.StringVal(h.Community))
+	}
+	if h.AdminLogin != "" {
+		body.SetAttributeValue("admin_login", cty.StringVal(h.AdminLogin))
+	}
+	if h.Type != "" {
+		body.SetAttributeValue("type", cty.StringVal(h.Type))
+	}
+	if h.Appliance != nil {
+		g.setDataRef(body, "appliance", "rtms_appliance", h.Appliance.ID)
+	}
+	g.main.Body().AppendNewline()
+
+	g.addImport("rtms_host", label, h.ID)
+
+	return label
+}
+
+func (g *generator) addService(s *rtms.MonitoringService, hostLabel string) {
+	label := g.namer("rtms_monitoring_service").unique(hostLabel + "_" + s.Name)
+
+	block := g.main.Body().AppendNewBlock("resource", []string{"rtms_monitoring_service", label})
+	body := block.Body()
+	if s.Appliance != nil {
+		g.setDataRef(body, "appliance", "rtms_appliance", s.Appliance.ID)
+	}
+	body.SetAttributeTraversal("host", hcl.Traversal{
+		hcl.TraverseRoot{Name: "rtms_host"},
+		hcl.TraverseAttr{Name: hostLabel},
+		hcl.TraverseAttr{Name: "id"},
+	})
+	body.SetAttributeValue("name", cty.StringVal(s.Name))
+	if s.Template != nil {
+		g.setDataRef(body, "template", "rtms_template", s.Template.ID)
+	}
+	if s.Description != "" {
+		body.SetAttributeValue("description", cty.StringVal(s.Description))
+	}
+	if s.MaxCheckAttempts != 0 {
+		body.SetAttributeValue("max_check_attempts", cty.NumberIntVal(int64(s.MaxCheckAttempts)))
+	}
+	if s.Plugin != nil {
+		g.setDataRef(body, "plugin", "rtms_plugin", s.Plugin.ID)
+	}
+	body.SetAttributeValue("is_monitored", cty.BoolVal(s.IsMonitored))
+	body.SetAttributeValue("notifications_enabled", cty.BoolVal(s.NotificationsEnabled))
+	if s.NiceName != "" {
+		body.SetAttributeValue("nice_name", cty.StringVal(s.NiceName))
+	}
+	if s.Keywords != "" {
+		body.SetAttributeValue("keywords", cty.StringVal(s.Keywords))
+	}
+	if s.Severity != 0 {
+		body.SetAttributeValue("severity", cty.NumberIntVal(int64(s.Severity)))
+	}
+	body.SetAttributeValue("only_notify_if_critical", cty.BoolVal(s.OnlyNotifyIfCritical))
+	if s.NormalCheckInterval != 0 {
+		body.SetAttributeValue("normal_check_interval", cty.NumberIntVal(int64(s.NormalCheckInterval)))
+	}
+	if s.RetryCheckInterval != 0 {
+		body.SetAttributeValue("retry_check_interval", cty.NumberIntVal(int64(s.RetryCheckInterval)))
+	}
+	if s.TimePeriod != nil {
+		g.setDataRef(body, "time_period", "rtms_timeperiod", s.TimePeriod.ID)
+	}
+	if s.CheckPeriod != nil {
+		g.setDataRef(body, "check_period", "rtms_checkperiod", s.CheckPeriod.ID)
+	}
+	if len(s.TicketCatalogsItems) > 0 {
+		body.SetAttributeTraversal("ticket_catalogs_items", dataTraversal("rtms_typology", g.typologyNames[typologyKey(s.TicketCatalogsItems)]))
+	}
+	if s.AutoProcessing {
+		body.SetAttributeValue("auto_processing", cty.True)
+	}
+	if s.ResponsibleTeam != nil {
+		g.setDataRef(body, "responsible_team", "rtms_team", s.ResponsibleTeam.ID)
+	}
+	// The help text is usually inherited from the template and edited in
+	// the RTMS console, so it is left out of the managed attributes.
+	if s.Help != "" {
+		lifecycle := body.AppendNewBlock("lifecycle", nil).Body()
+		lifecycle.SetAttributeRaw("ignore_changes", hclwrite.TokensForTuple([]hclwrite.Tokens{
+			hclwrite.TokensForIdentifier("help"),
+		}))
+	}
+	g.main.Body().AppendNewline()
+
+	g.addImport("rtms_monitoring_service", label, s.ID)
+}
+
+func (g *generator) setDataRef(body *hclwrite.Body, attribute, dataType string, id rtms.ID) {
+	body.SetAttributeTraversal(attribute, dataTraversal(dataType, g.dataNames[dataType][id]))
+}
+
+func dataTraversal(dataType, label string) hcl.Traversal {
+	return hcl.Traversal{
+		hcl.TraverseRoot{Name: "data"},
+		hcl.TraverseAttr{Name: dataType},
+		hcl.TraverseAttr{Name: label},
+		hcl.TraverseAttr{Name: "id"},
+	}
+}
+
+func (g *generator) addImport(resourceType, label string, id rtms.ID) {
+	body := g.imports.Body().AppendNewBlock("import", nil).Body()
+	body.SetAttributeTraversal("to", hcl.Traversal{
+		hcl.TraverseRoot{Name: resourceType},
+		hcl.TraverseAttr{Name: label},
+	})
+	body.SetAttributeValue("id", cty.StringVal(id.String()))
+	g.imports.Body().AppendNewline()
+}
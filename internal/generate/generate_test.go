@@ -0,0 +1,115 @@
+package generate
+
+import (
+	"context"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Bithault/terraform-provider-rtms/rtms"
+	"github.com/Bithault/terraform-provider-rtms/rtms/rtmstest"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files in testdata")
+
+// TestRun generates the configuration of a tenant served by rtmstest and
+// compares it with the files in testdata/run. Run "go test -update" to
+// rewrite them after a deliberate change.
+func TestRun(t *testing.T) {
+	server := rtmstest.NewServer()
+	defer server.Close()
+	team := server.AddTeam("Équipe réseau")
+	client := server.Client()
+	ctx := context.Background()
+
+	createHost := func(in *rtms.HostInput) int {
+		t.Helper()
+		id, err := client.CreateHost(ctx, in)
+		if err != nil {
+			t.Fatalf("CreateHost: %v", err)
+		}
+		return id
+	}
+	createService := func(in *rtms.MonitoringServiceInput) {
+		t.Helper()
+		if _, err := client.CreateMonitoringService(ctx, in); err != nil {
+			t.Fatalf("CreateMonitoringService: %v", err)
+		}
+	}
+
+	web := createHost(&rtms.HostInput{
+		Name:       rtms.String("web.01"),
+		Alias:      rtms.String("Serveur web"),
+		Address:    rtms.String("192.0.2.1"),
+		AdminLogin: rtms.String("admin"),
+		Appliance:  rtms.Int(1),
+	})
+	// Gets the same label as web.01, suffixed.
+	other := createHost(&rtms.HostInput{
+		Name:      rtms.String("web_01"),
+		Alias:     rtms.String("Other web server"),
+		Address:   rtms.String("192.0.2.2"),
+		Type:      rtms.String("switch"),
+		Community: rtms.String("public"),
+		Appliance: rtms.Int(2),
+	})
+
+	createService(&rtms.MonitoringServiceInput{
+		Host:                rtms.Int(web),
+		Name:                rtms.String("PING"),
+		Template:            rtms.Int(1),
+		Plugin:              rtms.Int(1),
+		PluginArgs:          rtms.String("-w 100,20%"),
+		ResponsibleTeam:     rtms.Int(int(team.ID)),
+		TimePeriod:          rtms.Int(1),
+		CheckPeriod:         rtms.Int(2),
+		TicketCatalogsItems: &[]int{1, 2},
+		Help:                rtms.String("See the wiki"),
+	})
+	createService(&rtms.MonitoringServiceInput{
+		Host:           rtms.Int(web),
+		Name:           rtms.String("HTTP"),
+		Template:       rtms.Int(1),
+		Plugin:         rtms.Int(2),
+		Description:    rtms.String("Page d'accueil"),
+		Severity:       rtms.Int(4),
+		AutoProcessing: rtms.Bool(true),
+	})
+	createService(&rtms.MonitoringServiceInput{
+		Host:                rtms.Int(other),
+		Name:                rtms.String("PING"),
+		Template:            rtms.Int(2),
+		ResponsibleTeam:     rtms.Int(int(team.ID)),
+		TicketCatalogsItems: &[]int{1, 2},
+	})
+
+	out := t.TempDir()
+	if err := Run(ctx, client, out); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+
+	for _, name := range []string{MainFile, CommonFile, ImportsFile} {
+		got, err := os.ReadFile(filepath.Join(out, name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		golden := filepath.Join("testdata", "run", name+".golden")
+		if *update {
+			if err := os.MkdirAll(filepath.Dir(golden), 0o755); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.WriteFile(golden, got, 0o644); err != nil {
+				t.Fatal(err)
+			}
+			continue
+		}
+		want, err := os.ReadFile(golden)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(got) != string(want) {
+			t.Errorf("%s differs from %s:\n%s", name, golden, got)
+		}
+	}
+}
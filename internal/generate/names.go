@@ -0,0 +1,80 @@
+package generate
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// removeDiacritics strips accents and other combining marks, so that "Équipe
+// réseau" becomes "Equipe reseau".
+func removeDiacritics(s string) string {
+	var b strings.Builder
+	for _, r := range norm.NFD.String(s) {
+		if !unicode.Is(unicode.Mn, r) {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// resourceName turns an RTMS object name into a valid Terraform block label.
+func resourceName(s string) string {
+	var b strings.Builder
+	for _, r := range removeDiacritics(s) {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '_', r == '-':
+			b.WriteRune(r)
+		default:
+			b.WriteByte('_')
+		}
+	}
+
+	name := b.String()
+	if name == "" || (!unicode.IsLetter(rune(name[0])) && name[0] != '_') {
+		name = "_" + name
+	}
+	return name
+}
+
+// namer hands out unique resource names within one block type.
+type namer struct {
+	seen map[string]int
+}
+
+func newNamer() *namer {
+	return &namer{seen: map[string]int{}}
+}
+
+// unique returns resourceName(s), suffixed with _1, _2, ... when that name
+// was already handed out.
+func (n *namer) unique(s string) string {
+	name := resourceName(s)
+	if _, ok := n.seen[name]; !ok {
+		n.seen[name] = 0
+		return name
+	}
+
+	for {
+		n.seen[name]++
+		candidate := fmt.Sprintf("%s_%d", name, n.seen[name])
+		if _, ok := n.seen[candidate]; !ok {
+			n.seen[candidate] = 0
+			return candidate
+		}
+	}
+}
+
+// camelCase capitalizes each word of s and joins them, so that
+// "Informatique de production" becomes "InformatiqueDeProduction".
+func camelCase(s string) string {
+	var b strings.Builder
+	for _, word := range strings.Fields(removeDiacritics(s)) {
+		runes := []rune(word)
+		runes[0] = unicode.ToUpper(runes[0])
+		b.WriteString(string(runes))
+	}
+	return b.String()
+}
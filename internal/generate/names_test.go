@@ -0,0 +1,77 @@
+package generate
+
+import "testing"
+
+func TestRemoveDiacritics(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"", ""},
+		{"web-01", "web-01"},
+		{"Équipe réseau", "Equipe reseau"},
+		{"Contrôleur àçèïõü", "Controleur aceiou"},
+		{"Ångström", "Angstrom"},
+		// Letters that are not decomposed into a base letter and a mark are
+		// kept.
+		{"Straße Øre", "Straße Øre"},
+	}
+	for _, tt := range tests {
+		if got := removeDiacritics(tt.in); got != tt.want {
+			t.Errorf("removeDiacritics(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestResourceName(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"web-01", "web-01"},
+		{"srv_db", "srv_db"},
+		{"srv.example.com", "srv_example_com"},
+		{"Équipe réseau", "Equipe_reseau"},
+		{"CPU / Load", "CPU___Load"},
+		{"01-web", "_01-web"},
+		{"-web", "_-web"},
+		{"_web", "_web"},
+		{"", "_"},
+		{"Øre", "_re"},
+	}
+	for _, tt := range tests {
+		if got := resourceName(tt.in); got != tt.want {
+			t.Errorf("resourceName(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestNamerUnique(t *testing.T) {
+	n := newNamer()
+	var got []string
+	// "web 01" and "web_01" map to the same label, and "web_01_1" is taken
+	// before the namer needs it as a suffix.
+	for _, name := range []string{"web_01", "web 01", "web_01_1", "web.01", "db", "Db", "db"} {
+		got = append(got, n.unique(name))
+	}
+	want := []string{"web_01", "web_01_1", "web_01_1_1", "web_01_2", "db", "Db", "db_1"}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("unique returned %q, want %q", got, want)
+		}
+	}
+}
+
+func TestCamelCase(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"Informatique de production", "InformatiqueDeProduction"},
+		{"réseau  étendu", "ReseauEtendu"},
+		{"Servers", "Servers"},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := camelCase(tt.in); got != tt.want {
+			t.Errorf("camelCase(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/Bithault/terraform-provider-rtms/rtms"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type applianceDataSource struct {
+	client *rtms.Client
+}
+
+type applianceDataSourceModel struct {
+	ID        types.Int64  `tfsdk:"id"`
+	Name      types.String `tfsdk:"name"`
+	Alias     types.String `tfsdk:"alias"`
+	Appliance types.String `tfsdk:"appliance"`
+}
+
+func newApplianceDataSource() datasource.DataSource {
+	return &applianceDataSource{}
+}
+
+func (d *applianceDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_appliance"
+}
+
+func (d *applianceDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	attributes := idOrNameAttributes()
+	attributes["alias"] = schema.StringAttribute{
+		Computed: true,
+	}
+	attributes["appliance"] = schema.StringAttribute{
+		Computed:    true,
+		Description: "The address of the appliance",
+	}
+	resp.Schema = schema.Schema{
+		Attributes: attributes,
+	}
+}
+
+func (d *applianceDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	d.client = clientFrom(req.ProviderData, &resp.Diagnostics)
+}
+
+func (d *applianceDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data applianceDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	appliances, err := d.client.ListAppliances(ctx)
+	if err != nil {
+		resp.Diagnostics.Append(diagFromErr("Unable to list appliances", err)...)
+		return
+	}
+
+	appliance, err := findByIDOrName(data.ID, data.Name, "appliance", appliances, func(a rtms.Appliance) (int, string) { return int(a.ID), a.Name })
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to find appliance", err.Error())
+		return
+	}
+
+	data.ID = types.Int64Value(int64(appliance.ID))
+	data.Name = types.StringValue(appliance.Name)
+	data.Alias = types.StringValue(appliance.Alias)
+	data.Appliance = types.StringValue(appliance.Address)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,61 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// providerDefaultsModel is the defaults block of the provider configuration.
+// Its values are planned for the resources that leave the matching argument
+// unset.
+type providerDefaultsModel struct {
+	Appliance       types.Int64 `tfsdk:"appliance"`
+	Template        types.Int64 `tfsdk:"template"`
+	TimePeriod      types.Int64 `tfsdk:"time_period"`
+	CheckPeriod     types.Int64 `tfsdk:"check_period"`
+	ResponsibleTeam types.Int64 `tfsdk:"responsible_team"`
+}
+
+func defaultsBlock() schema.SingleNestedBlock {
+	return schema.SingleNestedBlock{
+		Description: "Values used by the resources that leave the matching argument unset",
+		Attributes: map[string]schema.Attribute{
+			"appliance": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Appliance of rtms_host and rtms_monitoring_service",
+			},
+			"template": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Template of rtms_monitoring_service",
+			},
+			"time_period": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Time period of rtms_monitoring_service",
+			},
+			"check_period": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Check period of rtms_monitoring_service",
+			},
+			"responsible_team": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Responsible team of rtms_monitoring_service",
+			},
+		},
+	}
+}
+
+// planDefault sets attribute in the plan to the provider default def when
+// the configuration leaves it unset, so that the value shows in the plan.
+func planDefault(ctx context.Context, config tfsdk.Config, plan *tfsdk.Plan, diags *diag.Diagnostics, attribute string, def types.Int64) {
+	var configured types.Int64
+	diags.Append(config.GetAttribute(ctx, path.Root(attribute), &configured)...)
+	if diags.HasError() || !configured.IsNull() || def.IsNull() {
+		return
+	}
+	diags.Append(plan.SetAttribute(ctx, path.Root(attribute), def)...)
+}
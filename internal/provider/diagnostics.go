@@ -0,0 +1,99 @@
+package provider
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/Bithault/terraform-provider-rtms/rtms"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+)
+
+// diagFromErr turns an error returned by the RTMS client into diagnostics,
+// using summary for errors that carry no more specific information.
+// Validation errors get one diagnostic per rejected field. Malformed
+// responses get a dedicated summary so they are not mistaken for a provider
+// crash or a bad configuration.
+func diagFromErr(summary string, err error) diag.Diagnostics {
+	return diagFromErrAt(summary, path.Empty(), nil, err)
+}
+
+// diagFromErrAt is diagFromErr for a request writing the object at parent,
+// whose schema has attributes. Validation errors are attached to the
+// attribute matching the rejected field so Terraform points at the offending
+// line of configuration, or to parent when there is no such attribute.
+func diagFromErrAt(summary string, parent path.Path, attributes map[string]schema.Attribute, err error) diag.Diagnostics {
+	var apiErr *rtms.APIError
+	if errors.As(err, &apiErr) && apiErr.Validation != nil {
+		return validationDiagnostics(parent, attributes, apiErr.Validation)
+	}
+
+	var diags diag.Diagnostics
+
+	var decodeErr *rtms.DecodeError
+	if errors.As(err, &decodeErr) {
+		detail := decodeErr.Err.Error()
+		if decodeErr.Field != "" {
+			detail = fmt.Sprintf("Field %q: %s", decodeErr.Field, detail)
+		}
+		diags.AddError("Unexpected response from the RTMS API",
+			fmt.Sprintf("%s\n\nResponse body: %s", detail, rtms.TruncateBody(decodeErr.Body)))
+		return diags
+	}
+
+	diags.AddError(summary, err.Error())
+	return diags
+}
+
+func validationDiagnostics(parent path.Path, attributes map[string]schema.Attribute, validationError *rtms.ValidationError) diag.Diagnostics {
+	fields := make([]string, 0, len(validationError.Errors.Children))
+	for field := range validationError.Errors.Children {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	var diags diag.Diagnostics
+	for _, field := range fields {
+		attribute := apiFieldToAttribute(field)
+		attributePath := parent
+		if _, ok := attributes[attribute]; ok {
+			attributePath = parent.AtName(attribute)
+		}
+		for _, errorMsg := range validationError.Errors.Children[field].Errors {
+			summary := fmt.Sprintf("Invalid value for %s", attribute)
+			detail := fmt.Sprintf("%s: %s", validationError.Message, errorMsg)
+			if attributePath.Equal(path.Empty()) {
+				diags.AddError(summary, detail)
+			} else {
+				diags.AddAttributeError(attributePath, summary, detail)
+			}
+		}
+	}
+
+	if len(diags) == 0 {
+		diags.AddError("API Validation Error",
+			fmt.Sprintf("Status Code: %d. Message: %s", validationError.Code, validationError.Message))
+	}
+
+	return diags
+}
+
+// apiFieldToAttribute converts an API field name such as "maxCheckAttempts"
+// to the matching Terraform attribute name, "max_check_attempts".
+func apiFieldToAttribute(field string) string {
+	var b strings.Builder
+	for i, r := range field {
+		if unicode.IsUpper(r) {
+			if i > 0 {
+				b.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
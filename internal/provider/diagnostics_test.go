@@ -0,0 +1,151 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/Bithault/terraform-provider-rtms/rtms"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+func TestAPIFieldToAttribute(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"name", "name"},
+		{"maxCheckAttempts", "max_check_attempts"},
+		{"ticketCatalogsItems", "ticket_catalogs_items"},
+		{"adminLogin", "admin_login"},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := apiFieldToAttribute(tt.in); got != tt.want {
+			t.Errorf("apiFieldToAttribute(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+// TestDiagFromErrAtValidation checks that each error of a validation error
+// becomes a diagnostic on the matching attribute.
+func TestDiagFromErrAtValidation(t *testing.T) {
+	attributes := monitoringServiceSchema(context.Background()).Attributes
+	err := testValidationError(t, `{
+  "code": 400,
+  "message": "Validation Failed",
+  "errors": {"children": {
+    "maxCheckAttempts": {"errors": ["This value should be greater than or equal to 1.", "This value is not valid."]},
+    "name": {"errors": ["This value should not be blank."]},
+    "unknownField": {"errors": ["This value is not valid."]},
+    "plugin": {}
+  }}
+}`)
+
+	tests := []struct {
+		name   string
+		parent path.Path
+		want   []string
+	}{
+		{
+			name:   "root",
+			parent: path.Empty(),
+			want: []string{
+				"max_check_attempts: Invalid value for max_check_attempts: Validation Failed: This value should be greater than or equal to 1.",
+				"max_check_attempts: Invalid value for max_check_attempts: Validation Failed: This value is not valid.",
+				"name: Invalid value for name: Validation Failed: This value should not be blank.",
+				": Invalid value for unknown_field: Validation Failed: This value is not valid.",
+			},
+		},
+		{
+			name:   "nested block",
+			parent: path.Root("service").AtListIndex(1),
+			want: []string{
+				"service[1].max_check_attempts: Invalid value for max_check_attempts: Validation Failed: This value should be greater than or equal to 1.",
+				"service[1].max_check_attempts: Invalid value for max_check_attempts: Validation Failed: This value is not valid.",
+				"service[1].name: Invalid value for name: Validation Failed: This value should not be blank.",
+				"service[1]: Invalid value for unknown_field: Validation Failed: This value is not valid.",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := testDiagnostics(diagFromErrAt("Unable to create monitoring service", tt.parent, attributes, err))
+			if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("diagFromErrAt returned\n%q\nwant\n%q", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestDiagFromErrAtServiceBlock checks that the fields of a service missing
+// from the service block of rtms_host, set from the host itself, are
+// reported on the block.
+func TestDiagFromErrAtServiceBlock(t *testing.T) {
+	err := testValidationError(t, `{
+  "code": 400,
+  "message": "Validation Failed",
+  "errors": {"children": {
+    "appliance": {"errors": ["This value is not valid."]},
+    "host": {"errors": ["This value is not valid."]},
+    "plugin": {"errors": ["This value is not valid."]}
+  }}
+}`)
+	got := testDiagnostics(diagFromErrAt("Unable to create service", path.Root("service").AtListIndex(0), hostServiceBlock().NestedObject.Attributes, err))
+	want := []string{
+		"service[0]: Invalid value for appliance: Validation Failed: This value is not valid.",
+		"service[0]: Invalid value for host: Validation Failed: This value is not valid.",
+		"service[0].plugin: Invalid value for plugin: Validation Failed: This value is not valid.",
+	}
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("diagFromErrAt returned\n%q\nwant\n%q", got, want)
+	}
+}
+
+// TestDiagFromErrValidation checks that validation errors not tied to a
+// schema get diagnostics without a path, and that a validation error with no
+// field errors still gets one.
+func TestDiagFromErrValidation(t *testing.T) {
+	got := testDiagnostics(diagFromErr("Unable to list hosts", testValidationError(t, `{
+  "code": 400,
+  "message": "Validation Failed",
+  "errors": {"children": {"appliance": {"errors": ["This value is not valid."]}}}
+}`)))
+	want := []string{": Invalid value for appliance: Validation Failed: This value is not valid."}
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("diagFromErr returned %q, want %q", got, want)
+	}
+
+	got = testDiagnostics(diagFromErr("Unable to list hosts", testValidationError(t, `{
+  "code": 400,
+  "message": "Validation Failed"
+}`)))
+	want = []string{": API Validation Error: Status Code: 400. Message: Validation Failed"}
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("diagFromErr returned %q, want %q", got, want)
+	}
+}
+
+func testValidationError(t *testing.T, body string) error {
+	t.Helper()
+	var validationError rtms.ValidationError
+	if err := json.Unmarshal([]byte(body), &validationError); err != nil {
+		t.Fatal(err)
+	}
+	return &rtms.APIError{StatusCode: 400, Body: []byte(body), Validation: &validationError}
+}
+
+// testDiagnostics formats diags as "path: summary: detail", the path being
+// empty for diagnostics not attached to an attribute.
+func testDiagnostics(diags diag.Diagnostics) []string {
+	var formatted []string
+	for _, d := range diags {
+		var attributePath string
+		if withPath, ok := d.(diag.DiagnosticWithPath); ok {
+			attributePath = withPath.Path().String()
+		}
+		formatted = append(formatted, fmt.Sprintf("%s: %s: %s", attributePath, d.Summary(), d.Detail()))
+	}
+	return formatted
+}
@@ -0,0 +1,123 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Bithault/terraform-provider-rtms/rtms"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type hostDataSource struct {
+	client *rtms.Client
+}
+
+type hostDataSourceModel struct {
+	hostAttributes
+	ID types.Int64 `tfsdk:"id"`
+}
+
+func newHostDataSource() datasource.DataSource {
+	return &hostDataSource{}
+}
+
+func (d *hostDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_host"
+}
+
+func (d *hostDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.Int64Attribute{
+				Optional: true,
+				Computed: true,
+				Validators: []validator.Int64{
+					int64validator.ExactlyOneOf(path.MatchRoot("name"), path.MatchRoot("address")),
+				},
+			},
+			"name": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+			},
+			"address": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+			},
+			"alias": schema.StringAttribute{
+				Computed: true,
+			},
+			"community": schema.StringAttribute{
+				Computed: true,
+			},
+			"admin_login": schema.StringAttribute{
+				Computed: true,
+			},
+			"type": schema.StringAttribute{
+				Computed: true,
+			},
+			"appliance": schema.Int64Attribute{
+				Computed: true,
+			},
+		},
+	}
+}
+
+func (d *hostDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	d.client = clientFrom(req.ProviderData, &resp.Diagnostics)
+}
+
+func (d *hostDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data hostDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id := int(data.ID.ValueInt64())
+	if !data.Name.IsNull() {
+		host, err := findHostByName(ctx, d.client, data.Name.ValueString())
+		if err != nil {
+			resp.Diagnostics.Append(diagFromErr("Unable to find host", err)...)
+			return
+		}
+		id = int(host.ID)
+	} else if !data.Address.IsNull() {
+		address := data.Address.ValueString()
+		hosts, err := d.client.ListHosts(ctx, nil)
+		if err != nil {
+			resp.Diagnostics.Append(diagFromErr("Unable to list hosts", err)...)
+			return
+		}
+		host, err := findOne("host", hosts, func(h rtms.Host) bool { return h.Address == address }, fmt.Sprintf("address %q", address))
+		if err != nil {
+			resp.Diagnostics.AddError("Unable to find host", err.Error())
+			return
+		}
+		id = int(host.ID)
+	}
+
+	// The list endpoint only returns a summary of each host.
+	host, err := d.client.GetHost(ctx, id)
+	if err != nil {
+		resp.Diagnostics.Append(diagFromErr("Unable to read host", err)...)
+		return
+	}
+
+	data.ID = types.Int64Value(int64(host.ID))
+	setHostModel(&data.hostAttributes, host)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// applianceID returns the id of appliance, or null when the API did not
+// return one.
+func applianceID(appliance *rtms.Appliance) types.Int64 {
+	if appliance == nil {
+		return types.Int64Null()
+	}
+	return types.Int64Value(int64(appliance.ID))
+}
@@ -0,0 +1,54 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccHostDataSource(t *testing.T) {
+	name := acctest.RandomWithPrefix(testAccNamePrefix)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckHostDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCatalogConfig() + fmt.Sprintf(`
+resource "rtms_host" "test" {
+  name        = %q
+  alias       = "Acceptance test"
+  address     = "192.0.2.50"
+  admin_login = "admin"
+  appliance   = data.rtms_appliance.test.id
+}
+
+data "rtms_host" "by_name" {
+  name = rtms_host.test.name
+}
+
+data "rtms_host" "by_address" {
+  address = rtms_host.test.address
+}
+`, name),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckHostDataSource("data.rtms_host.by_name"),
+					testAccCheckHostDataSource("data.rtms_host.by_address"),
+				),
+			},
+		},
+	})
+}
+
+// testAccCheckHostDataSource checks that the data source at address reads
+// the same attributes as rtms_host.test.
+func testAccCheckHostDataSource(address string) resource.TestCheckFunc {
+	var checks []resource.TestCheckFunc
+	for _, attribute := range []string{"id", "name", "alias", "address", "community", "admin_login", "type", "appliance"} {
+		checks = append(checks, resource.TestCheckResourceAttrPair(address, attribute, "rtms_host.test", attribute))
+	}
+	return resource.ComposeAggregateTestCheckFunc(checks...)
+}
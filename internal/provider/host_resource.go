@@ -0,0 +1,481 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"strconv"
+	"strings"
+
+	"github.com/Bithault/terraform-provider-rtms/rtms"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type hostResource struct {
+	client   *rtms.Client
+	defaults providerDefaultsModel
+}
+
+type hostResourceModel struct {
+	hostResourceModelV0
+	AdminPasswordVersion types.Int64 `tfsdk:"admin_password_version"`
+	Services             types.List  `tfsdk:"service"`
+}
+
+// hostResourceModelV0 is the state of schema version 0, also written by the
+// former SDK based provider, where admin_password was stored in state.
+type hostResourceModelV0 struct {
+	hostAttributes
+	ID            types.String   `tfsdk:"id"`
+	AdminPassword types.String   `tfsdk:"admin_password"`
+	Timeouts      timeouts.Value `tfsdk:"timeouts"`
+}
+
+// hostAttributes holds the host attributes read back from the API, shared by
+// rtms_host and the rtms_host data source.
+type hostAttributes struct {
+	Name       types.String `tfsdk:"name"`
+	Alias      types.String `tfsdk:"alias"`
+	Address    types.String `tfsdk:"address"`
+	Community  types.String `tfsdk:"community"`
+	AdminLogin types.String `tfsdk:"admin_login"`
+	Type       types.String `tfsdk:"type"`
+	Appliance  types.Int64  `tfsdk:"appliance"`
+}
+
+func newHostResource() resource.Resource {
+	return &hostResource{}
+}
+
+func (r *hostResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_host"
+}
+
+// Optional arguments are also computed: when they are left out of the
+// configuration, the value set by RTMS is kept instead of showing a diff.
+func (r *hostResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = hostSchema(ctx)
+}
+
+func hostSchema(ctx context.Context) schema.Schema {
+	return schema.Schema{
+		Version: 1,
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Required: true,
+			},
+			"alias": schema.StringAttribute{
+				Required: true,
+			},
+			"address": schema.StringAttribute{
+				Required: true,
+				Validators: []validator.String{
+					validAddress{},
+				},
+			},
+			"community": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"admin_login": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"admin_password": schema.StringAttribute{
+				Optional:    true,
+				Sensitive:   true,
+				WriteOnly:   true,
+				Description: "Only sent when the host is created or admin_password_version changes, never stored in the state. Requires Terraform 1.11 or later",
+			},
+			"admin_password_version": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Change this value to send admin_password to RTMS again",
+				Validators: []validator.Int64{
+					int64validator.AlsoRequires(path.MatchRoot("admin_password")),
+				},
+			},
+			"type": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Validators: []validator.String{
+					stringvalidator.OneOf(rtms.HostTypes()...),
+				},
+			},
+			"appliance": schema.Int64Attribute{
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"service": hostServiceBlock(),
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+// UpgradeState migrates the state of schema version 0, dropping the admin
+// password that used to be stored in it.
+func (r *hostResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	priorSchema := hostSchema(ctx)
+	priorSchema.Version = 0
+	priorSchema.Attributes = maps.Clone(priorSchema.Attributes)
+	delete(priorSchema.Attributes, "admin_password_version")
+	priorSchema.Blocks = maps.Clone(priorSchema.Blocks)
+	delete(priorSchema.Blocks, "service")
+	priorSchema.Attributes["admin_password"] = schema.StringAttribute{
+		Optional:  true,
+		Computed:  true,
+		Sensitive: true,
+	}
+
+	return map[int64]resource.StateUpgrader{
+		0: {
+			PriorSchema:   &priorSchema,
+			StateUpgrader: upgradeHostStateV0,
+		},
+	}
+}
+
+func upgradeHostStateV0(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+	var prior hostResourceModelV0
+	resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	prior.AdminPassword = types.StringNull()
+	state := hostResourceModel{
+		hostResourceModelV0:  prior,
+		AdminPasswordVersion: types.Int64Null(),
+		Services:             types.ListNull(hostServiceBlock().NestedObject.Type()),
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// ValidateConfig requires a community for the host types monitored over SNMP
+// and distinct names for the services.
+func (r *hostResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config hostResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	validateHostServices(ctx, config.Services, &resp.Diagnostics)
+
+	if config.Type.IsUnknown() || config.Community.IsUnknown() || !rtms.IsSNMPHostType(config.Type.ValueString()) {
+		return
+	}
+	if config.Community.ValueString() == "" {
+		resp.Diagnostics.AddAttributeError(path.Root("community"), "Missing community",
+			fmt.Sprintf("Hosts of type %q are monitored over SNMP and need a community.", config.Type.ValueString()))
+	}
+}
+
+func (r *hostResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if data := providerDataFrom(req.ProviderData, &resp.Diagnostics); data != nil {
+		r.client = data.client
+		r.defaults = data.defaults
+	}
+}
+
+// ModifyPlan fills the appliance and the arguments left out of the service
+// blocks from the provider defaults.
+func (r *hostResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+	planDefault(ctx, req.Config, &resp.Plan, &resp.Diagnostics, "appliance", r.defaults.Appliance)
+
+	var configured, planned, prior types.List
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("service"), &configured)...)
+	resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root("service"), &planned)...)
+	if !req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("service"), &prior)...)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	planned = r.planHostServices(ctx, configured, planned, prior, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("service"), planned)...)
+}
+
+func (r *hostResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan hostResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	timeout, diags := plan.Timeouts.Create(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	// Write-only arguments are only available in the configuration.
+	var adminPassword types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("admin_password"), &adminPassword)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	host := &rtms.HostInput{
+		Name:          stringPointer(plan.Name),
+		Alias:         stringPointer(plan.Alias),
+		Address:       stringPointer(plan.Address),
+		Community:     stringPointer(plan.Community),
+		AdminLogin:    stringPointer(plan.AdminLogin),
+		AdminPassword: stringPointer(adminPassword),
+		Type:          stringPointer(plan.Type),
+		Appliance:     intPointer(plan.Appliance),
+	}
+
+	hostID, err := r.client.CreateHost(ctx, host)
+	if err != nil {
+		resp.Diagnostics.Append(diagFromErrAt("Unable to create host", path.Empty(), hostSchema(ctx).Attributes, err)...)
+		return
+	}
+
+	// Record the id right away so the host is tracked even if reading it
+	// back fails.
+	plan.ID = types.StringValue(strconv.Itoa(hostID))
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), plan.ID)...)
+
+	created, err := r.client.GetHost(ctx, hostID)
+	if err != nil {
+		resp.Diagnostics.Append(diagFromErr("Unable to read host", err)...)
+		return
+	}
+
+	setHostModel(&plan.hostAttributes, created)
+
+	services, diags := r.syncHostServices(ctx, hostID, plan.Appliance, false, false,
+		hostServices(ctx, plan.Services, &resp.Diagnostics), nil)
+	resp.Diagnostics.Append(diags...)
+	plan.Services = hostServicesList(ctx, services, &resp.Diagnostics)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *hostResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state hostResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	timeout, diags := state.Timeouts.Read(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	id, err := strconv.Atoi(state.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid host id", fmt.Sprintf("invalid host id %q: %s", state.ID.ValueString(), err))
+		return
+	}
+
+	host, err := r.client.GetHost(ctx, id)
+	if rtms.IsNotFound(err) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.Append(diagFromErr("Unable to read host", err)...)
+		return
+	}
+
+	setHostModel(&state.hostAttributes, host)
+
+	services, diags := r.refreshHostServices(ctx, hostServices(ctx, state.Services, &resp.Diagnostics))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	state.Services = hostServicesList(ctx, services, &resp.Diagnostics)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// setHostModel copies host to the attributes read back from the API. The
+// admin password is never returned.
+func setHostModel(m *hostAttributes, host *rtms.Host) {
+	m.Name = types.StringValue(host.Name)
+	m.Alias = types.StringValue(host.Alias)
+	m.Address = types.StringValue(host.Address)
+	m.Community = types.StringValue(host.Community)
+	m.AdminLogin = types.StringValue(host.AdminLogin)
+	m.Type = types.StringValue(host.Type)
+	m.Appliance = applianceID(host.Appliance)
+}
+
+func (r *hostResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state hostResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	timeout, diags := plan.Timeouts.Update(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	id, err := strconv.Atoi(state.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid host id", fmt.Sprintf("invalid host id %q: %s", state.ID.ValueString(), err))
+		return
+	}
+
+	host := &rtms.HostInput{}
+
+	if changed(plan.Name, state.Name) {
+		host.Name = stringPointer(plan.Name)
+	}
+	if changed(plan.Alias, state.Alias) {
+		host.Alias = stringPointer(plan.Alias)
+	}
+	if changed(plan.Address, state.Address) {
+		host.Address = stringPointer(plan.Address)
+	}
+	if changed(plan.Community, state.Community) {
+		host.Community = stringPointer(plan.Community)
+	}
+	if changed(plan.AdminLogin, state.AdminLogin) {
+		host.AdminLogin = stringPointer(plan.AdminLogin)
+	}
+	if changed(plan.AdminPasswordVersion, state.AdminPasswordVersion) {
+		var adminPassword types.String
+		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("admin_password"), &adminPassword)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		host.AdminPassword = stringPointer(adminPassword)
+	}
+	if changed(plan.Type, state.Type) {
+		host.Type = stringPointer(plan.Type)
+	}
+	if changed(plan.Appliance, state.Appliance) {
+		host.Appliance = intPointer(plan.Appliance)
+	}
+
+	if *host != (rtms.HostInput{}) {
+		if err := r.client.PatchHost(ctx, id, host); err != nil {
+			resp.Diagnostics.Append(diagFromErrAt("Unable to update host", path.Empty(), hostSchema(ctx).Attributes, err)...)
+			return
+		}
+	}
+
+	updated, err := r.client.GetHost(ctx, id)
+	if err != nil {
+		resp.Diagnostics.Append(diagFromErr("Unable to read host", err)...)
+		return
+	}
+
+	setHostModel(&plan.hostAttributes, updated)
+
+	// Services imported with the host are adopted by name rather than
+	// created twice.
+	services, diags := r.syncHostServices(ctx, id, plan.Appliance, changed(plan.Appliance, state.Appliance), true,
+		hostServices(ctx, plan.Services, &resp.Diagnostics), hostServices(ctx, state.Services, &resp.Diagnostics))
+	resp.Diagnostics.Append(diags...)
+	plan.Services = hostServicesList(ctx, services, &resp.Diagnostics)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *hostResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state hostResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	timeout, diags := state.Timeouts.Delete(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	id, err := strconv.Atoi(state.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid host id", fmt.Sprintf("invalid host id %q: %s", state.ID.ValueString(), err))
+		return
+	}
+
+	// The services must go first: RTMS refuses to delete a host that still
+	// has some.
+	resp.Diagnostics.Append(r.deleteHostServices(ctx, hostServices(ctx, state.Services, &resp.Diagnostics))...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// A host already deleted outside of Terraform is not an error.
+	if err := r.client.DeleteHost(ctx, id); err != nil && !rtms.IsNotFound(err) {
+		resp.Diagnostics.Append(diagFromErr("Unable to delete host", err)...)
+	}
+}
+
+// ImportState accepts either a numeric host id or "name:<host name>".
+func (r *hostResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	id := req.ID
+	if name, ok := strings.CutPrefix(id, "name:"); ok {
+		host, err := findHostByName(ctx, r.client, name)
+		if err != nil {
+			resp.Diagnostics.Append(diagFromErr("Unable to find host", err)...)
+			return
+		}
+		id = host.ID.String()
+	} else if _, err := strconv.Atoi(id); err != nil {
+		resp.Diagnostics.AddError("Invalid import id",
+			fmt.Sprintf("invalid import id %q, expected a numeric host id or name:<host name>", id))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+}
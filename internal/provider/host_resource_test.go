@@ -0,0 +1,562 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"regexp"
+	"strconv"
+	"testing"
+
+	"github.com/Bithault/terraform-provider-rtms/rtms"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccHostResource(t *testing.T) {
+	name := acctest.RandomWithPrefix(testAccNamePrefix)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckHostDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccHostConfig(name, "Acceptance test"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckHostRemote("rtms_host.test", name, "Acceptance test"),
+					resource.TestCheckResourceAttr("rtms_host.test", "name", name),
+					resource.TestCheckResourceAttr("rtms_host.test", "alias", "Acceptance test"),
+					resource.TestCheckResourceAttr("rtms_host.test", "address", "192.0.2.10"),
+					resource.TestCheckResourceAttr("rtms_host.test", "type", rtms.HostTypeServer),
+					resource.TestCheckResourceAttrPair("rtms_host.test", "appliance", "data.rtms_appliance.test", "id"),
+					resource.TestCheckResourceAttrSet("rtms_host.test", "id"),
+				),
+			},
+			{
+				ResourceName:            "rtms_host.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"admin_password"},
+			},
+			{
+				Config: testAccHostConfig(name, "Acceptance test, updated"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckHostRemote("rtms_host.test", name, "Acceptance test, updated"),
+					resource.TestCheckResourceAttr("rtms_host.test", "alias", "Acceptance test, updated"),
+				),
+			},
+		},
+	})
+}
+
+// TestAccHostResource_adminPassword checks that the write-only admin password
+// is sent on creation and when its version is bumped, and never stored.
+func TestAccHostResource_adminPassword(t *testing.T) {
+	name := acctest.RandomWithPrefix(testAccNamePrefix)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		// admin_password is write-only.
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_11_0),
+		},
+		CheckDestroy: testAccCheckHostDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccHostPasswordConfig(name, "first-password", 1),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckHostAdminPassword("rtms_host.test", "first-password"),
+					resource.TestCheckResourceAttr("rtms_host.test", "admin_password_version", "1"),
+					resource.TestCheckNoResourceAttr("rtms_host.test", "admin_password"),
+				),
+			},
+			{
+				ResourceName:            "rtms_host.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"admin_password", "admin_password_version"},
+			},
+			{
+				// Without a new version the password is not sent again.
+				Config: testAccHostPasswordConfig(name, "ignored-password", 1),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckHostAdminPassword("rtms_host.test", "first-password"),
+					resource.TestCheckNoResourceAttr("rtms_host.test", "admin_password"),
+				),
+			},
+			{
+				Config: testAccHostPasswordConfig(name, "second-password", 2),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckHostAdminPassword("rtms_host.test", "second-password"),
+					resource.TestCheckResourceAttr("rtms_host.test", "admin_password_version", "2"),
+					resource.TestCheckNoResourceAttr("rtms_host.test", "admin_password"),
+				),
+			},
+		},
+	})
+}
+
+// TestAccHostResource_disappears checks that a host deleted outside of
+// Terraform is removed from the state instead of failing the refresh.
+func TestAccHostResource_disappears(t *testing.T) {
+	name := acctest.RandomWithPrefix(testAccNamePrefix)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckHostDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccHostConfig(name, "Acceptance test"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckHostRemote("rtms_host.test", name, "Acceptance test"),
+					testAccCheckHostDisappears("rtms_host.test"),
+				),
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+// TestAccHostResource_services covers the service blocks of a host: they are
+// matched by name, and an imported host adopts its existing services.
+func TestAccHostResource_services(t *testing.T) {
+	name := acctest.RandomWithPrefix(testAccNamePrefix)
+	var httpID string
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_7_0),
+		},
+		CheckDestroy: resource.ComposeTestCheckFunc(
+			testAccCheckHostDestroy,
+			testAccCheckHostServicesDestroy(&httpID),
+		),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccHostServicesConfig(name, `
+  service {
+    name     = "PING"
+    template = data.rtms_template.test.id
+  }
+
+  service {
+    name        = "HTTP"
+    template    = data.rtms_template.test.id
+    plugin      = data.rtms_plugin.test.id
+    plugin_args = "-u /health"
+    description = "Web"
+  }
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckHostServicesRemote("rtms_host.test", map[string]string{"PING": "", "HTTP": "Web"}),
+					resource.TestCheckResourceAttr("rtms_host.test", "service.#", "2"),
+					resource.TestCheckResourceAttr("rtms_host.test", "service.0.name", "PING"),
+					resource.TestCheckResourceAttrSet("rtms_host.test", "service.0.id"),
+					resource.TestCheckResourceAttr("rtms_host.test", "service.1.name", "HTTP"),
+					resource.TestCheckResourceAttr("rtms_host.test", "service.1.plugin_args", "-u /health"),
+					resource.TestCheckResourceAttrPair("rtms_host.test", "service.1.template", "data.rtms_template.test", "id"),
+					resource.TestCheckResourceAttrPair("rtms_host.test", "service.1.plugin", "data.rtms_plugin.test", "id"),
+					testAccCheckHostServiceID("rtms_host.test", 1, &httpID),
+				),
+			},
+			{
+				// HTTP is moved and updated in place, PING is deleted and
+				// DISK created.
+				Config: testAccHostServicesConfig(name, `
+  service {
+    name        = "HTTP"
+    template    = data.rtms_template.test.id
+    plugin      = data.rtms_plugin.test.id
+    plugin_args = "-u /health"
+    description = "Web, updated"
+  }
+
+  service {
+    name     = "DISK"
+    template = data.rtms_template.test.id
+    keywords = "disk"
+  }
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckHostServicesRemote("rtms_host.test", map[string]string{"HTTP": "Web, updated", "DISK": ""}),
+					resource.TestCheckResourceAttr("rtms_host.test", "service.#", "2"),
+					resource.TestCheckResourceAttr("rtms_host.test", "service.0.name", "HTTP"),
+					resource.TestCheckResourceAttr("rtms_host.test", "service.0.description", "Web, updated"),
+					resource.TestCheckResourceAttrPtr("rtms_host.test", "service.0.id", &httpID),
+					resource.TestCheckResourceAttr("rtms_host.test", "service.1.name", "DISK"),
+					resource.TestCheckResourceAttr("rtms_host.test", "service.1.keywords", "disk"),
+				),
+			},
+			{
+				// The arguments removed from the blocks are cleared in RTMS,
+				// the flags and numbers keep their value.
+				Config: testAccHostServicesConfig(name, `
+  service {
+    name     = "HTTP"
+    template = data.rtms_template.test.id
+  }
+
+  service {
+    name     = "DISK"
+    template = data.rtms_template.test.id
+  }
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckHostServicesRemote("rtms_host.test", map[string]string{"HTTP": "", "DISK": ""}),
+					testAccCheckHostServiceCleared("rtms_host.test", "HTTP"),
+					testAccCheckHostServiceCleared("rtms_host.test", "DISK"),
+					resource.TestCheckResourceAttrPtr("rtms_host.test", "service.0.id", &httpID),
+					resource.TestCheckNoResourceAttr("rtms_host.test", "service.0.description"),
+					resource.TestCheckNoResourceAttr("rtms_host.test", "service.0.plugin"),
+					resource.TestCheckNoResourceAttr("rtms_host.test", "service.1.keywords"),
+					resource.TestCheckResourceAttrSet("rtms_host.test", "service.0.severity"),
+					resource.TestCheckResourceAttrSet("rtms_host.test", "service.0.max_check_attempts"),
+				),
+			},
+			{
+				// Forget the host, keeping it and its services in RTMS.
+				Config: testAccCatalogConfig() + `
+removed {
+  from = rtms_host.test
+
+  lifecycle {
+    destroy = false
+  }
+}
+`,
+			},
+			{
+				// The imported host has no service blocks in state: its
+				// services are adopted instead of created again.
+				Config: fmt.Sprintf(`
+import {
+  to = rtms_host.test
+  id = "name:%s"
+}
+`, name) + testAccHostServicesConfig(name, `
+  service {
+    name        = "HTTP"
+    template    = data.rtms_template.test.id
+    plugin      = data.rtms_plugin.test.id
+    plugin_args = "-u /health"
+    description = "Web, updated"
+  }
+
+  service {
+    name     = "DISK"
+    template = data.rtms_template.test.id
+    keywords = "disk"
+  }
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckHostServicesRemote("rtms_host.test", map[string]string{"HTTP": "Web, updated", "DISK": ""}),
+					resource.TestCheckResourceAttrPtr("rtms_host.test", "service.0.id", &httpID),
+				),
+			},
+		},
+	})
+}
+
+// TestAccHostResource_invalidConfig checks the host type and the community
+// of SNMP hosts at plan time.
+func TestAccHostResource_invalidConfig(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccHostTypeConfig(`type = "mainframe"`),
+				ExpectError: regexp.MustCompile(`Invalid Attribute Value Match`),
+			},
+			{
+				Config:      testAccHostTypeConfig(`type = "switch"`),
+				ExpectError: regexp.MustCompile(`Missing community`),
+			},
+			{
+				Config:             testAccHostTypeConfig("type = \"switch\"\n  community = \"public\""),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+// TestAccHostResource_serviceValidationError checks that a service rejected
+// by the API is reported on the attribute of its block, not of the host.
+func TestAccHostResource_serviceValidationError(t *testing.T) {
+	name := acctest.RandomWithPrefix(testAccNamePrefix)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckHostDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccHostServicesConfig(name, `
+  service {
+    name     = "PING"
+    template = data.rtms_template.test.id
+  }
+
+  service {
+    name     = "HTTP"
+    template = data.rtms_template.test.id
+    plugin   = 999999
+  }
+`),
+				// The diagnostic quotes the plugin line of the HTTP block.
+				ExpectError: regexp.MustCompile(`Invalid value for plugin[\s\S]*plugin\s+= 999999`),
+			},
+		},
+	})
+}
+
+// TestHostUpgradeStateV0 checks that the migration of the state of schema
+// version 0 drops the admin password and keeps the other attributes.
+func TestHostUpgradeStateV0(t *testing.T) {
+	upgraded := testUpgradeState(t, newHostResource(), 0, `{
+  "id": "42",
+  "name": "web-01",
+  "alias": "Web server",
+  "address": "192.0.2.1",
+  "community": "public",
+  "admin_login": "admin",
+  "admin_password": "s3cret",
+  "type": "server",
+  "appliance": 1,
+  "timeouts": {"create": "5m", "read": null, "update": null, "delete": null}
+}`)
+
+	var state hostResourceModel
+	if diags := upgraded.Get(context.Background(), &state); diags.HasError() {
+		t.Fatalf("reading the upgraded state: %v", diags)
+	}
+	for _, attr := range []struct {
+		name      string
+		got, want attr.Value
+	}{
+		{"id", state.ID, types.StringValue("42")},
+		{"name", state.Name, types.StringValue("web-01")},
+		{"alias", state.Alias, types.StringValue("Web server")},
+		{"address", state.Address, types.StringValue("192.0.2.1")},
+		{"community", state.Community, types.StringValue("public")},
+		{"admin_login", state.AdminLogin, types.StringValue("admin")},
+		{"type", state.Type, types.StringValue("server")},
+		{"appliance", state.Appliance, types.Int64Value(1)},
+		{"admin_password", state.AdminPassword, types.StringNull()},
+		{"admin_password_version", state.AdminPasswordVersion, types.Int64Null()},
+		// Terraform has no null blocks, the framework sends an empty list.
+		{"service", state.Services, types.ListValueMust(hostServiceBlock().NestedObject.Type(), nil)},
+	} {
+		if !attr.got.Equal(attr.want) {
+			t.Errorf("%s = %s, want %s", attr.name, attr.got, attr.want)
+		}
+	}
+	if create := state.Timeouts.Object.Attributes()["create"]; !create.Equal(types.StringValue("5m")) {
+		t.Errorf("timeouts.create = %s, want \"5m\"", create)
+	}
+}
+
+func testAccHostServicesConfig(name, services string) string {
+	return testAccCatalogConfig() + fmt.Sprintf(`
+resource "rtms_host" "test" {
+  name      = %q
+  alias     = "Acceptance test"
+  address   = "192.0.2.40"
+  appliance = data.rtms_appliance.test.id
+%s}
+`, name, services)
+}
+
+func testAccHostTypeConfig(attributes string) string {
+	return fmt.Sprintf(`
+resource "rtms_host" "test" {
+  name    = "invalid"
+  alias   = "Acceptance test"
+  address = "192.0.2.60"
+  %s
+}
+`, attributes)
+}
+
+func testAccHostConfig(name, alias string) string {
+	return testAccCatalogConfig() + fmt.Sprintf(`
+resource "rtms_host" "test" {
+  name        = %q
+  alias       = %q
+  address     = "192.0.2.10"
+  admin_login = "admin"
+  appliance   = data.rtms_appliance.test.id
+}
+`, name, alias)
+}
+
+func testAccHostPasswordConfig(name, password string, passwordVersion int) string {
+	return testAccCatalogConfig() + fmt.Sprintf(`
+resource "rtms_host" "test" {
+  name                   = %q
+  alias                  = "Acceptance test"
+  address                = "192.0.2.11"
+  admin_login            = "admin"
+  admin_password         = %q
+  admin_password_version = %d
+  appliance              = data.rtms_appliance.test.id
+}
+`, name, password, passwordVersion)
+}
+
+// testAccCheckHostRemote checks the host as returned by the API.
+func testAccCheckHostRemote(address, name, alias string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		id, err := testAccResourceID(s, address)
+		if err != nil {
+			return err
+		}
+		host, err := testAccClient().GetHost(context.Background(), id)
+		if err != nil {
+			return err
+		}
+		if host.Name != name || host.Alias != alias {
+			return fmt.Errorf("host %d is named %q with alias %q, want %q and %q", id, host.Name, host.Alias, name, alias)
+		}
+		return nil
+	}
+}
+
+// testAccCheckHostAdminPassword checks the admin password received by the
+// fake API. The real API never returns it, so nothing is checked against a
+// tenant.
+func testAccCheckHostAdminPassword(address, want string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if testAccServer == nil {
+			return nil
+		}
+		id, err := testAccResourceID(s, address)
+		if err != nil {
+			return err
+		}
+		if got, _ := testAccServer.HostAdminPassword(id); got != want {
+			return fmt.Errorf("host %d has admin password %q, want %q", id, got, want)
+		}
+		return nil
+	}
+}
+
+// testAccCheckHostServicesRemote checks that the services of the host
+// returned by the API are exactly want, a map of names to descriptions.
+func testAccCheckHostServicesRemote(address string, want map[string]string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		id, err := testAccResourceID(s, address)
+		if err != nil {
+			return err
+		}
+		services, err := testAccClient().ListMonitoringServices(context.Background(), &rtms.MonitoringServiceListOptions{Host: id})
+		if err != nil {
+			return err
+		}
+		got := make(map[string]string, len(services))
+		for _, service := range services {
+			got[service.Name] = service.Description
+		}
+		if len(services) != len(want) || !maps.Equal(got, want) {
+			return fmt.Errorf("host %d has services %v, want %v", id, got, want)
+		}
+		return nil
+	}
+}
+
+// testAccCheckHostServiceCleared checks that the description, keywords and
+// plugin of the service of the host named name are unset in RTMS.
+func testAccCheckHostServiceCleared(address, name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		id, err := testAccResourceID(s, address)
+		if err != nil {
+			return err
+		}
+		services, err := testAccClient().ListMonitoringServices(context.Background(), &rtms.MonitoringServiceListOptions{Host: id})
+		if err != nil {
+			return err
+		}
+		for _, service := range services {
+			if service.Name != name {
+				continue
+			}
+			if service.Description != "" || service.Keywords != "" || service.Plugin != nil {
+				return fmt.Errorf("service %q was not cleared: %+v", name, service)
+			}
+			return nil
+		}
+		return fmt.Errorf("host %d has no service %q", id, name)
+	}
+}
+
+// testAccCheckHostServiceID stores the id of the service block at index.
+func testAccCheckHostServiceID(address string, index int, id *string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[address]
+		if !ok {
+			return fmt.Errorf("%s not found in state", address)
+		}
+		*id = rs.Primary.Attributes[fmt.Sprintf("service.%d.id", index)]
+		return nil
+	}
+}
+
+// testAccCheckHostServicesDestroy checks that the service with the given id
+// was deleted with its host.
+func testAccCheckHostServicesDestroy(id *string) resource.TestCheckFunc {
+	return func(*terraform.State) error {
+		serviceID, err := strconv.Atoi(*id)
+		if err != nil {
+			return err
+		}
+		_, err = testAccClient().GetMonitoringService(context.Background(), serviceID)
+		if err == nil {
+			return fmt.Errorf("service %d still exists", serviceID)
+		}
+		if !rtms.IsNotFound(err) {
+			return err
+		}
+		return nil
+	}
+}
+
+func testAccCheckHostDisappears(address string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		id, err := testAccResourceID(s, address)
+		if err != nil {
+			return err
+		}
+		return testAccClient().DeleteHost(context.Background(), id)
+	}
+}
+
+func testAccCheckHostDestroy(s *terraform.State) error {
+	client := testAccClient()
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "rtms_host" {
+			continue
+		}
+		id, err := strconv.Atoi(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		_, err = client.GetHost(context.Background(), id)
+		if err == nil {
+			return fmt.Errorf("host %d still exists", id)
+		}
+		if !rtms.IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,534 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/Bithault/terraform-provider-rtms/rtms"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// hostServiceModel is a service block of rtms_host. The texts and references
+// left out of the block are left to the template, and cleared when removed
+// from it. The flags and numbers, which RTMS cannot unset, keep the value
+// RTMS has when left out.
+type hostServiceModel struct {
+	ID                   types.String `tfsdk:"id"`
+	Name                 types.String `tfsdk:"name"`
+	Template             types.Int64  `tfsdk:"template"`
+	Description          types.String `tfsdk:"description"`
+	Plugin               types.Int64  `tfsdk:"plugin"`
+	PluginArgs           types.String `tfsdk:"plugin_args"`
+	Keywords             types.String `tfsdk:"keywords"`
+	IsMonitored          types.Bool   `tfsdk:"is_monitored"`
+	NotificationsEnabled types.Bool   `tfsdk:"notifications_enabled"`
+	Severity             types.Int64  `tfsdk:"severity"`
+	MaxCheckAttempts     types.Int64  `tfsdk:"max_check_attempts"`
+	NormalCheckInterval  types.Int64  `tfsdk:"normal_check_interval"`
+	RetryCheckInterval   types.Int64  `tfsdk:"retry_check_interval"`
+	TimePeriod           types.Int64  `tfsdk:"time_period"`
+	CheckPeriod          types.Int64  `tfsdk:"check_period"`
+	ResponsibleTeam      types.Int64  `tfsdk:"responsible_team"`
+}
+
+func hostServiceBlock() schema.ListNestedBlock {
+	return schema.ListNestedBlock{
+		Description: "Services of the host, matched by name. They run on the appliance of the host.",
+		NestedObject: schema.NestedBlockObject{
+			Attributes: map[string]schema.Attribute{
+				"id": schema.StringAttribute{
+					Computed: true,
+				},
+				"name": schema.StringAttribute{
+					Required:    true,
+					Description: "Unique among the services of the host",
+				},
+				// The attributes below are computed so that ModifyPlan can
+				// fill them from the provider defaults.
+				"template": schema.Int64Attribute{
+					Optional:    true,
+					Computed:    true,
+					Description: "Required unless set in the defaults block of the provider",
+				},
+				"time_period": schema.Int64Attribute{
+					Optional: true,
+					Computed: true,
+				},
+				"check_period": schema.Int64Attribute{
+					Optional: true,
+					Computed: true,
+				},
+				"responsible_team": schema.Int64Attribute{
+					Optional: true,
+					Computed: true,
+				},
+				"description": schema.StringAttribute{
+					Optional: true,
+				},
+				"plugin": schema.Int64Attribute{
+					Optional: true,
+				},
+				"plugin_args": schema.StringAttribute{
+					Optional: true,
+				},
+				"keywords": schema.StringAttribute{
+					Optional: true,
+				},
+				// The attributes below are computed so that, when left
+				// out, they keep the value RTMS has.
+				"is_monitored": schema.BoolAttribute{
+					Optional: true,
+					Computed: true,
+				},
+				"notifications_enabled": schema.BoolAttribute{
+					Optional: true,
+					Computed: true,
+				},
+				"severity": schema.Int64Attribute{
+					Optional: true,
+					Computed: true,
+				},
+				"max_check_attempts": schema.Int64Attribute{
+					Optional:   true,
+					Computed:   true,
+					Validators: []validator.Int64{int64validator.AtLeast(1)},
+				},
+				"normal_check_interval": schema.Int64Attribute{
+					Optional:   true,
+					Computed:   true,
+					Validators: []validator.Int64{int64validator.AtLeast(1)},
+				},
+				"retry_check_interval": schema.Int64Attribute{
+					Optional:   true,
+					Computed:   true,
+					Validators: []validator.Int64{int64validator.AtLeast(1)},
+				},
+			},
+		},
+	}
+}
+
+// hostServices converts the service blocks of a host. Null and unknown
+// lists give no services.
+func hostServices(ctx context.Context, v types.List, diags *diag.Diagnostics) []hostServiceModel {
+	if v.IsNull() || v.IsUnknown() {
+		return nil
+	}
+	var services []hostServiceModel
+	diags.Append(v.ElementsAs(ctx, &services, false)...)
+	return services
+}
+
+func hostServicesList(ctx context.Context, services []hostServiceModel, diags *diag.Diagnostics) types.List {
+	if services == nil {
+		services = []hostServiceModel{}
+	}
+	list, d := types.ListValueFrom(ctx, hostServiceBlock().NestedObject.Type(), services)
+	diags.Append(d...)
+	return list
+}
+
+// validateHostServices rejects service blocks sharing a name, as they could
+// not be told apart, and checks the intervals as rtms_monitoring_service
+// does.
+func validateHostServices(ctx context.Context, v types.List, diags *diag.Diagnostics) {
+	seen := make(map[string]bool)
+	for i, service := range hostServices(ctx, v, diags) {
+		servicePath := path.Root("service").AtListIndex(i)
+
+		if !service.Name.IsUnknown() {
+			name := service.Name.ValueString()
+			if seen[name] {
+				diags.AddAttributeError(servicePath.AtName("name"), "Duplicate service name",
+					fmt.Sprintf("The host already has a service block named %q.", name))
+			}
+			seen[name] = true
+		}
+
+		normal, retry := service.NormalCheckInterval, service.RetryCheckInterval
+		if normal.IsNull() || normal.IsUnknown() || retry.IsNull() || retry.IsUnknown() {
+			continue
+		}
+		if retry.ValueInt64() > normal.ValueInt64() {
+			diags.AddAttributeError(servicePath.AtName("retry_check_interval"), "Invalid retry_check_interval",
+				fmt.Sprintf("retry_check_interval (%d) must not be greater than normal_check_interval (%d).", retry.ValueInt64(), normal.ValueInt64()))
+		}
+	}
+}
+
+// planHostServices completes the planned service blocks: the arguments left
+// out of a block are filled from the provider defaults, and the services
+// already created keep the id, flags and numbers of the service with the same
+// name.
+func (r *hostResource) planHostServices(ctx context.Context, configured, planned, prior types.List, diags *diag.Diagnostics) types.List {
+	if planned.IsUnknown() || configured.IsUnknown() {
+		return planned
+	}
+	config := hostServices(ctx, configured, diags)
+	plan := hostServices(ctx, planned, diags)
+	priorByName := make(map[string]hostServiceModel)
+	for _, service := range hostServices(ctx, prior, diags) {
+		priorByName[service.Name.ValueString()] = service
+	}
+	if diags.HasError() || len(config) != len(plan) {
+		return planned
+	}
+
+	for i := range plan {
+		servicePath := path.Root("service").AtListIndex(i)
+		if config[i].Template.IsNull() {
+			if r.defaults.Template.IsNull() {
+				diags.AddAttributeError(servicePath.AtName("template"), "Missing template",
+					"Set template in the service block or in the defaults block of the provider.")
+			}
+			plan[i].Template = r.defaults.Template
+		}
+		for _, d := range []struct {
+			configured types.Int64
+			planned    *types.Int64
+			def        types.Int64
+		}{
+			{config[i].TimePeriod, &plan[i].TimePeriod, r.defaults.TimePeriod},
+			{config[i].CheckPeriod, &plan[i].CheckPeriod, r.defaults.CheckPeriod},
+			{config[i].ResponsibleTeam, &plan[i].ResponsibleTeam, r.defaults.ResponsibleTeam},
+		} {
+			if d.configured.IsNull() {
+				*d.planned = d.def
+			}
+		}
+
+		plan[i].ID = types.StringUnknown()
+		priorService, ok := priorByName[plan[i].Name.ValueString()]
+		if !ok || plan[i].Name.IsUnknown() {
+			continue
+		}
+		plan[i].ID = priorService.ID
+		if config[i].IsMonitored.IsNull() {
+			plan[i].IsMonitored = priorService.IsMonitored
+		}
+		if config[i].NotificationsEnabled.IsNull() {
+			plan[i].NotificationsEnabled = priorService.NotificationsEnabled
+		}
+		for _, v := range []struct {
+			configured types.Int64
+			planned    *types.Int64
+			prior      types.Int64
+		}{
+			{config[i].Severity, &plan[i].Severity, priorService.Severity},
+			{config[i].MaxCheckAttempts, &plan[i].MaxCheckAttempts, priorService.MaxCheckAttempts},
+			{config[i].NormalCheckInterval, &plan[i].NormalCheckInterval, priorService.NormalCheckInterval},
+			{config[i].RetryCheckInterval, &plan[i].RetryCheckInterval, priorService.RetryCheckInterval},
+		} {
+			if v.configured.IsNull() {
+				*v.planned = v.prior
+			}
+		}
+	}
+
+	return hostServicesList(ctx, plan, diags)
+}
+
+// input returns the request setting the arguments of m that differ from
+// prior. With a zero prior, every argument set in the block is sent. Texts and
+// references removed from the block are cleared.
+func (m hostServiceModel) input(prior hostServiceModel) *rtms.MonitoringServiceInput {
+	in := &rtms.MonitoringServiceInput{}
+	if changed(m.Name, prior.Name) {
+		in.Name = stringPointer(m.Name)
+	}
+	if changed(m.Template, prior.Template) {
+		in.Template = clearedRef(m.Template)
+	}
+	if changed(m.Description, prior.Description) {
+		in.Description = clearedString(m.Description)
+	}
+	if changed(m.Plugin, prior.Plugin) {
+		in.Plugin = clearedRef(m.Plugin)
+	}
+	if changed(m.PluginArgs, prior.PluginArgs) {
+		in.PluginArgs = clearedString(m.PluginArgs)
+	}
+	if changed(m.Keywords, prior.Keywords) {
+		in.Keywords = clearedString(m.Keywords)
+	}
+	if changed(m.IsMonitored, prior.IsMonitored) {
+		in.IsMonitored = boolPointer(m.IsMonitored)
+	}
+	if changed(m.NotificationsEnabled, prior.NotificationsEnabled) {
+		in.NotificationsEnabled = boolPointer(m.NotificationsEnabled)
+	}
+	if changed(m.Severity, prior.Severity) {
+		in.Severity = intPointer(m.Severity)
+	}
+	if changed(m.MaxCheckAttempts, prior.MaxCheckAttempts) {
+		in.MaxCheckAttempts = intPointer(m.MaxCheckAttempts)
+	}
+	if changed(m.NormalCheckInterval, prior.NormalCheckInterval) {
+		in.NormalCheckInterval = intPointer(m.NormalCheckInterval)
+	}
+	if changed(m.RetryCheckInterval, prior.RetryCheckInterval) {
+		in.RetryCheckInterval = intPointer(m.RetryCheckInterval)
+	}
+	if changed(m.TimePeriod, prior.TimePeriod) {
+		in.TimePeriod = clearedRef(m.TimePeriod)
+	}
+	if changed(m.CheckPeriod, prior.CheckPeriod) {
+		in.CheckPeriod = clearedRef(m.CheckPeriod)
+	}
+	if changed(m.ResponsibleTeam, prior.ResponsibleTeam) {
+		in.ResponsibleTeam = clearedRef(m.ResponsibleTeam)
+	}
+	return in
+}
+
+// keepUnknown sets the flags and numbers of m still unknown, left out of a
+// block whose name was not known at plan time, to those of prior.
+func (m *hostServiceModel) keepUnknown(prior hostServiceModel) {
+	if m.IsMonitored.IsUnknown() {
+		m.IsMonitored = prior.IsMonitored
+	}
+	if m.NotificationsEnabled.IsUnknown() {
+		m.NotificationsEnabled = prior.NotificationsEnabled
+	}
+	for _, v := range []struct {
+		value *types.Int64
+		prior types.Int64
+	}{
+		{&m.Severity, prior.Severity},
+		{&m.MaxCheckAttempts, prior.MaxCheckAttempts},
+		{&m.NormalCheckInterval, prior.NormalCheckInterval},
+		{&m.RetryCheckInterval, prior.RetryCheckInterval},
+	} {
+		if v.value.IsUnknown() {
+			*v.value = v.prior
+		}
+	}
+}
+
+// clearedString returns the value sent for v, an empty string when v is null
+// so that the text is cleared.
+func clearedString(v types.String) *string {
+	if v.IsNull() {
+		return rtms.String("")
+	}
+	return stringPointer(v)
+}
+
+// clearedRef returns the value sent for v, 0 when v is null so that the
+// reference is unset.
+func clearedRef(v types.Int64) *int {
+	if v.IsNull() {
+		return rtms.Int(0)
+	}
+	return intPointer(v)
+}
+
+// refresh copies service to the arguments set in the block, and to the flags
+// and numbers.
+func (m *hostServiceModel) refresh(service *rtms.MonitoringService) {
+	m.Name = types.StringValue(service.Name)
+	refreshManaged(&m.Template, refID(service.Template, func(t *rtms.Template) rtms.ID { return t.ID }))
+	refreshManaged(&m.Description, types.StringValue(service.Description))
+	refreshManaged(&m.Plugin, refID(service.Plugin, func(p *rtms.Plugin) rtms.ID { return p.ID }))
+	refreshManaged(&m.Keywords, types.StringValue(service.Keywords))
+	m.IsMonitored = types.BoolValue(service.IsMonitored)
+	m.NotificationsEnabled = types.BoolValue(service.NotificationsEnabled)
+	m.Severity = types.Int64Value(int64(service.Severity))
+	m.MaxCheckAttempts = types.Int64Value(int64(service.MaxCheckAttempts))
+	m.NormalCheckInterval = types.Int64Value(int64(service.NormalCheckInterval))
+	m.RetryCheckInterval = types.Int64Value(int64(service.RetryCheckInterval))
+	refreshManaged(&m.TimePeriod, refID(service.TimePeriod, func(t *rtms.TimePeriod) rtms.ID { return t.ID }))
+	refreshManaged(&m.CheckPeriod, refID(service.CheckPeriod, func(t *rtms.TimePeriod) rtms.ID { return t.ID }))
+	refreshManaged(&m.ResponsibleTeam, refID(service.ResponsibleTeam, func(t *rtms.Team) rtms.ID { return t.ID }))
+}
+
+// refreshManaged sets v to the value read from the API, unless v is null
+// because the block leaves it to RTMS.
+func refreshManaged[T attr.Value](v *T, read T) {
+	if !(*v).IsNull() {
+		*v = read
+	}
+}
+
+// refID returns the id of an object embedded in a response, null if the
+// object is not set.
+func refID[T any](ref *T, id func(*T) rtms.ID) types.Int64 {
+	if ref == nil {
+		return types.Int64Null()
+	}
+	return types.Int64Value(int64(id(ref)))
+}
+
+// syncHostServices creates, updates and deletes the services of a host so
+// that current, the services in state, matches planned. Services are matched
+// by name. When adopt is set, a planned service missing from current but
+// already defined on the host in RTMS is updated instead of created again.
+// The returned services reflect the changes made, also when one of them
+// failed.
+func (r *hostResource) syncHostServices(ctx context.Context, hostID int, appliance types.Int64, applianceChanged, adopt bool, planned, current []hostServiceModel) ([]hostServiceModel, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	byName := make(map[string]hostServiceModel, len(current))
+	for _, service := range current {
+		byName[service.Name.ValueString()] = service
+	}
+	wanted := make(map[string]bool, len(planned))
+	for _, service := range planned {
+		wanted[service.Name.ValueString()] = true
+	}
+
+	// result lists the services in the planned order, followed by those
+	// that could not be deleted.
+	result := func() []hostServiceModel {
+		services := make([]hostServiceModel, 0, len(byName))
+		for _, list := range [][]hostServiceModel{planned, current} {
+			for _, service := range list {
+				name := service.Name.ValueString()
+				if s, ok := byName[name]; ok {
+					services = append(services, s)
+					delete(byName, name)
+				}
+			}
+		}
+		return services
+	}
+
+	// Deleting first frees the names of renamed services.
+	for _, service := range current {
+		name := service.Name.ValueString()
+		if wanted[name] {
+			continue
+		}
+		id, err := strconv.Atoi(service.ID.ValueString())
+		if err == nil {
+			err = r.client.DeleteMonitoringService(ctx, id)
+		}
+		if err != nil && !rtms.IsNotFound(err) {
+			diags.Append(diagFromErr(fmt.Sprintf("Unable to delete service %q", name), err)...)
+			return result(), diags
+		}
+		delete(byName, name)
+	}
+
+	var existing map[string]rtms.ID
+	for i, service := range planned {
+		name := service.Name.ValueString()
+		block := path.Root("service").AtListIndex(i)
+
+		if prior, ok := byName[name]; ok {
+			service.ID = prior.ID
+			service.keepUnknown(prior)
+			in := service.input(prior)
+			if applianceChanged {
+				in.Appliance = intPointer(appliance)
+			}
+			if *in != (rtms.MonitoringServiceInput{}) {
+				id, err := strconv.Atoi(prior.ID.ValueString())
+				if err == nil {
+					err = r.client.PatchMonitoringService(ctx, id, in)
+				}
+				if err != nil {
+					diags.Append(diagFromErrAt(fmt.Sprintf("Unable to update service %q", name), block, hostServiceBlock().NestedObject.Attributes, err)...)
+					return result(), diags
+				}
+			}
+			byName[name] = service
+			continue
+		}
+
+		in := service.input(hostServiceModel{})
+		in.Host = rtms.Int(hostID)
+		in.Appliance = intPointer(appliance)
+
+		if adopt && existing == nil {
+			services, err := r.client.ListMonitoringServices(ctx, &rtms.MonitoringServiceListOptions{Host: hostID})
+			if err != nil {
+				diags.Append(diagFromErr("Unable to list the services of the host", err)...)
+				return result(), diags
+			}
+			existing = make(map[string]rtms.ID, len(services))
+			for _, s := range services {
+				if s.Host != nil && int(s.Host.ID) == hostID {
+					existing[s.Name] = s.ID
+				}
+			}
+		}
+
+		id, ok := existing[name]
+		if ok {
+			if err := r.client.PatchMonitoringService(ctx, int(id), in); err != nil {
+				diags.Append(diagFromErrAt(fmt.Sprintf("Unable to update service %q", name), block, hostServiceBlock().NestedObject.Attributes, err)...)
+				return result(), diags
+			}
+		} else {
+			created, err := r.client.CreateMonitoringService(ctx, in)
+			if err != nil {
+				diags.Append(diagFromErrAt(fmt.Sprintf("Unable to create service %q", name), block, hostServiceBlock().NestedObject.Attributes, err)...)
+				return result(), diags
+			}
+			id = rtms.ID(created)
+		}
+		service.ID = types.StringValue(id.String())
+		byName[name] = service
+
+		// Reads back the flags and numbers left to RTMS.
+		read, err := r.client.GetMonitoringService(ctx, int(id))
+		if err != nil {
+			diags.Append(diagFromErr(fmt.Sprintf("Unable to read service %q", name), err)...)
+			return result(), diags
+		}
+		service.refresh(read)
+		byName[name] = service
+	}
+
+	return result(), diags
+}
+
+// refreshHostServices reads the services of a host back. Services deleted
+// outside of Terraform are dropped, so that they are planned for creation.
+func (r *hostResource) refreshHostServices(ctx context.Context, services []hostServiceModel) ([]hostServiceModel, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	refreshed := make([]hostServiceModel, 0, len(services))
+	for _, service := range services {
+		id, err := strconv.Atoi(service.ID.ValueString())
+		if err != nil {
+			diags.AddError("Invalid service id", fmt.Sprintf("invalid id %q for service %q: %s", service.ID.ValueString(), service.Name.ValueString(), err))
+			return services, diags
+		}
+		read, err := r.client.GetMonitoringService(ctx, id)
+		if rtms.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			diags.Append(diagFromErr(fmt.Sprintf("Unable to read service %q", service.Name.ValueString()), err)...)
+			return services, diags
+		}
+		service.refresh(read)
+		refreshed = append(refreshed, service)
+	}
+	return refreshed, diags
+}
+
+// deleteHostServices deletes the services of a host, which must be done
+// before the host can be deleted.
+func (r *hostResource) deleteHostServices(ctx context.Context, services []hostServiceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+	for _, service := range services {
+		id, err := strconv.Atoi(service.ID.ValueString())
+		if err != nil {
+			diags.AddError("Invalid service id", fmt.Sprintf("invalid id %q for service %q: %s", service.ID.ValueString(), service.Name.ValueString(), err))
+			continue
+		}
+		if err := r.client.DeleteMonitoringService(ctx, id); err != nil && !rtms.IsNotFound(err) {
+			diags.Append(diagFromErr(fmt.Sprintf("Unable to delete service %q", service.Name.ValueString()), err)...)
+		}
+	}
+	return diags
+}
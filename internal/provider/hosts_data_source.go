@@ -0,0 +1,182 @@
+package provider
+
+import (
+	"context"
+	"net"
+	"regexp"
+
+	"github.com/Bithault/terraform-provider-rtms/rtms"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type hostsDataSource struct {
+	client *rtms.Client
+}
+
+type hostsDataSourceModel struct {
+	ID          types.String               `tfsdk:"id"`
+	NameRegex   types.String               `tfsdk:"name_regex"`
+	Appliance   types.Int64                `tfsdk:"appliance"`
+	Type        types.String               `tfsdk:"type"`
+	AddressCIDR types.String               `tfsdk:"address_cidr"`
+	Hosts       []hostsDataSourceHostModel `tfsdk:"hosts"`
+}
+
+type hostsDataSourceHostModel struct {
+	ID        types.Int64  `tfsdk:"id"`
+	Name      types.String `tfsdk:"name"`
+	Alias     types.String `tfsdk:"alias"`
+	Address   types.String `tfsdk:"address"`
+	Type      types.String `tfsdk:"type"`
+	Appliance types.Int64  `tfsdk:"appliance"`
+}
+
+func newHostsDataSource() datasource.DataSource {
+	return &hostsDataSource{}
+}
+
+func (d *hostsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_hosts"
+}
+
+func (d *hostsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+			},
+			"name_regex": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only return hosts whose name matches this regular expression",
+				Validators: []validator.String{
+					validRegexp{},
+				},
+			},
+			"appliance": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Only return hosts monitored by this appliance",
+			},
+			"type": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only return hosts of this type",
+			},
+			"address_cidr": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only return hosts whose address is an IP in this CIDR block",
+				Validators: []validator.String{
+					validCIDR{},
+				},
+			},
+			"hosts": schema.ListNestedAttribute{
+				Computed: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.Int64Attribute{
+							Computed: true,
+						},
+						"name": schema.StringAttribute{
+							Computed: true,
+						},
+						"alias": schema.StringAttribute{
+							Computed: true,
+						},
+						"address": schema.StringAttribute{
+							Computed: true,
+						},
+						"type": schema.StringAttribute{
+							Computed: true,
+						},
+						"appliance": schema.Int64Attribute{
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *hostsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	d.client = clientFrom(req.ProviderData, &resp.Diagnostics)
+}
+
+func (d *hostsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data hostsDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	opts := &rtms.HostListOptions{
+		Appliance: int(data.Appliance.ValueInt64()),
+		Type:      data.Type.ValueString(),
+	}
+
+	// The validators already reject invalid filters, they are checked again
+	// so that a value getting past them fails the read instead of the
+	// plugin.
+	var nameRegex *regexp.Regexp
+	if !data.NameRegex.IsNull() {
+		var err error
+		nameRegex, err = regexp.Compile(data.NameRegex.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("name_regex"), "Invalid regular expression", err.Error())
+		}
+	}
+
+	var addressCIDR *net.IPNet
+	if !data.AddressCIDR.IsNull() {
+		var err error
+		_, addressCIDR, err = net.ParseCIDR(data.AddressCIDR.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("address_cidr"), "Invalid CIDR block", err.Error())
+		}
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	hosts, err := d.client.ListHosts(ctx, opts)
+	if err != nil {
+		resp.Diagnostics.Append(diagFromErr("Unable to list hosts", err)...)
+		return
+	}
+
+	data.Hosts = make([]hostsDataSourceHostModel, 0, len(hosts))
+	for _, host := range hosts {
+		// The appliance and type filters are also sent to the API, they are
+		// checked again here in case the endpoint ignores them.
+		appliance := applianceID(host.Appliance)
+		if opts.Appliance != 0 && appliance.ValueInt64() != int64(opts.Appliance) {
+			continue
+		}
+		if opts.Type != "" && host.Type != opts.Type {
+			continue
+		}
+		if nameRegex != nil && !nameRegex.MatchString(host.Name) {
+			continue
+		}
+		if addressCIDR != nil {
+			ip := net.ParseIP(host.Address)
+			if ip == nil || !addressCIDR.Contains(ip) {
+				continue
+			}
+		}
+
+		data.Hosts = append(data.Hosts, hostsDataSourceHostModel{
+			ID:        types.Int64Value(int64(host.ID)),
+			Name:      types.StringValue(host.Name),
+			Alias:     types.StringValue(host.Alias),
+			Address:   types.StringValue(host.Address),
+			Type:      types.StringValue(host.Type),
+			Appliance: appliance,
+		})
+	}
+
+	data.ID = types.StringValue(d.client.CloudTempleID())
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,130 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccHostsDataSource(t *testing.T) {
+	name := acctest.RandomWithPrefix(testAccNamePrefix)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckHostDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccHostsDataSourceConfig(name, fmt.Sprintf(`
+  name_regex   = "^%s-"
+  address_cidr = "192.0.2.0/28"
+`, name)),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.rtms_hosts.test", "hosts.#", "1"),
+					resource.TestCheckResourceAttrPair("data.rtms_hosts.test", "hosts.0.id", "rtms_host.inside", "id"),
+					resource.TestCheckResourceAttrPair("data.rtms_hosts.test", "hosts.0.appliance", "data.rtms_appliance.test", "id"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccHostsDataSource_invalidFilters(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+data "rtms_hosts" "test" {
+  name_regex = "web-("
+}
+`,
+				ExpectError: regexp.MustCompile(`Invalid regular expression`),
+			},
+			{
+				Config: `
+data "rtms_hosts" "test" {
+  address_cidr = "192.0.2.0"
+}
+`,
+				ExpectError: regexp.MustCompile(`Invalid CIDR block`),
+			},
+		},
+	})
+}
+
+// TestHostsDataSourceReadInvalidFilters checks that Read reports filters the
+// validators did not reject as errors rather than panicking.
+func TestHostsDataSourceReadInvalidFilters(t *testing.T) {
+	ctx := context.Background()
+	d := &hostsDataSource{}
+	var schemaResp datasource.SchemaResponse
+	d.Schema(ctx, datasource.SchemaRequest{}, &schemaResp)
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+
+	tests := []struct {
+		attribute string
+		value     string
+	}{
+		{"name_regex", "web-("},
+		{"address_cidr", "192.0.2.0"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.attribute, func(t *testing.T) {
+			values := make(map[string]tftypes.Value, len(objectType.AttributeTypes))
+			for name, typ := range objectType.AttributeTypes {
+				values[name] = tftypes.NewValue(typ, nil)
+			}
+			values[tt.attribute] = tftypes.NewValue(tftypes.String, tt.value)
+
+			req := datasource.ReadRequest{
+				Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, values)},
+			}
+			resp := datasource.ReadResponse{
+				State: tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, nil)},
+			}
+			d.Read(ctx, req, &resp)
+
+			if resp.Diagnostics.ErrorsCount() != 1 {
+				t.Fatalf("Read returned %v, want one error", resp.Diagnostics)
+			}
+			withPath, ok := resp.Diagnostics.Errors()[0].(diag.DiagnosticWithPath)
+			if !ok || !withPath.Path().Equal(path.Root(tt.attribute)) {
+				t.Errorf("Read returned %v, want an error on %s", resp.Diagnostics, tt.attribute)
+			}
+		})
+	}
+}
+
+func testAccHostsDataSourceConfig(name, filters string) string {
+	return testAccCatalogConfig() + fmt.Sprintf(`
+resource "rtms_host" "inside" {
+  name      = "%[1]s-inside"
+  alias     = "Acceptance test"
+  address   = "192.0.2.1"
+  appliance = data.rtms_appliance.test.id
+}
+
+resource "rtms_host" "outside" {
+  name      = "%[1]s-outside"
+  alias     = "Acceptance test"
+  address   = "192.0.2.100"
+  appliance = data.rtms_appliance.test.id
+}
+
+data "rtms_hosts" "test" {
+%[2]s
+  depends_on = [rtms_host.inside, rtms_host.outside]
+}
+`, name, filters)
+}
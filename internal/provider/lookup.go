@@ -0,0 +1,66 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Bithault/terraform-provider-rtms/rtms"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// findByIDOrName returns the single item matching the id or name set on a
+// data source. When both are set, the item is found by id and must have that
+// name.
+func findByIDOrName[T any](id types.Int64, name types.String, kind string, items []T, key func(T) (int, string)) (T, error) {
+	if !id.IsNull() {
+		want := int(id.ValueInt64())
+		item, err := findOne(kind, items, func(item T) bool {
+			itemID, _ := key(item)
+			return itemID == want
+		}, fmt.Sprintf("id %d", want))
+		if err != nil || name.IsNull() {
+			return item, err
+		}
+		if _, itemName := key(item); itemName != name.ValueString() {
+			var zero T
+			return zero, fmt.Errorf("the %s with id %d is named %q, not %q", kind, want, itemName, name.ValueString())
+		}
+		return item, nil
+	}
+
+	want := name.ValueString()
+	return findOne(kind, items, func(item T) bool {
+		_, itemName := key(item)
+		return itemName == want
+	}, fmt.Sprintf("name %q", want))
+}
+
+// findOne returns the single item accepted by match, or an error naming kind
+// and criteria when there is no match or more than one.
+func findOne[T any](kind string, items []T, match func(T) bool, criteria string) (T, error) {
+	var found []T
+	for _, item := range items {
+		if match(item) {
+			found = append(found, item)
+		}
+	}
+
+	var zero T
+	switch len(found) {
+	case 0:
+		return zero, fmt.Errorf("no %s found with %s", kind, criteria)
+	case 1:
+		return found[0], nil
+	default:
+		return zero, fmt.Errorf("%d %ss found with %s, use a more specific lookup", len(found), kind, criteria)
+	}
+}
+
+func findHostByName(ctx context.Context, client *rtms.Client, name string) (rtms.Host, error) {
+	hosts, err := client.ListHosts(ctx, nil)
+	if err != nil {
+		return rtms.Host{}, err
+	}
+
+	return findOne("host", hosts, func(h rtms.Host) bool { return h.Name == name }, fmt.Sprintf("name %q", name))
+}
@@ -0,0 +1,44 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/Bithault/terraform-provider-rtms/rtms"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestFindByIDOrName(t *testing.T) {
+	items := []rtms.Ref{{ID: 1, Name: "Linux"}, {ID: 2, Name: "Windows"}, {ID: 3, Name: "Copy"}, {ID: 4, Name: "Copy"}}
+	key := func(r rtms.Ref) (int, string) { return int(r.ID), r.Name }
+
+	tests := []struct {
+		name    string
+		id      types.Int64
+		byName  types.String
+		want    rtms.ID
+		wantErr string
+	}{
+		{"id", types.Int64Value(2), types.StringNull(), 2, ""},
+		{"name", types.Int64Null(), types.StringValue("Linux"), 1, ""},
+		{"id and matching name", types.Int64Value(2), types.StringValue("Windows"), 2, ""},
+		{"id and other name", types.Int64Value(2), types.StringValue("Linux"), 0, `the template with id 2 is named "Windows", not "Linux"`},
+		{"unknown id", types.Int64Value(9), types.StringNull(), 0, "no template found with id 9"},
+		{"unknown name", types.Int64Null(), types.StringValue("BSD"), 0, `no template found with name "BSD"`},
+		{"ambiguous name", types.Int64Null(), types.StringValue("Copy"), 0, `2 templates found with name "Copy", use a more specific lookup`},
+		{"id disambiguates name", types.Int64Value(4), types.StringValue("Copy"), 4, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := findByIDOrName(tt.id, tt.byName, "template", items, key)
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Fatalf("findByIDOrName returned %+v, %v, want error %q", got, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil || got.ID != tt.want {
+				t.Fatalf("findByIDOrName returned %+v, %v, want id %d", got, err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,608 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"strconv"
+	"strings"
+
+	"github.com/Bithault/terraform-provider-rtms/rtms"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type monitoringServiceResource struct {
+	client   *rtms.Client
+	defaults providerDefaultsModel
+}
+
+type monitoringServiceResourceModel struct {
+	monitoringServiceAttributes
+	TicketCatalogsItems types.Set `tfsdk:"ticket_catalogs_items"`
+}
+
+// monitoringServiceResourceModelV0 is the state of schema version 0, also
+// written by the former SDK based provider, where ticket_catalogs_items was
+// a list.
+type monitoringServiceResourceModelV0 struct {
+	monitoringServiceAttributes
+	TicketCatalogsItems types.List `tfsdk:"ticket_catalogs_items"`
+}
+
+// monitoringServiceAttributes holds the attributes shared by every schema
+// version.
+type monitoringServiceAttributes struct {
+	ID                   types.String   `tfsdk:"id"`
+	Appliance            types.Int64    `tfsdk:"appliance"`
+	Host                 types.Int64    `tfsdk:"host"`
+	Name                 types.String   `tfsdk:"name"`
+	Template             types.Int64    `tfsdk:"template"`
+	Description          types.String   `tfsdk:"description"`
+	MaxCheckAttempts     types.Int64    `tfsdk:"max_check_attempts"`
+	Plugin               types.Int64    `tfsdk:"plugin"`
+	PluginArgs           types.String   `tfsdk:"plugin_args"`
+	IsMonitored          types.Bool     `tfsdk:"is_monitored"`
+	NotificationsEnabled types.Bool     `tfsdk:"notifications_enabled"`
+	NiceName             types.String   `tfsdk:"nice_name"`
+	Keywords             types.String   `tfsdk:"keywords"`
+	Help                 types.String   `tfsdk:"help"`
+	Severity             types.Int64    `tfsdk:"severity"`
+	OnlyNotifyIfCritical types.Bool     `tfsdk:"only_notify_if_critical"`
+	NormalCheckInterval  types.Int64    `tfsdk:"normal_check_interval"`
+	RetryCheckInterval   types.Int64    `tfsdk:"retry_check_interval"`
+	TimePeriod           types.Int64    `tfsdk:"time_period"`
+	CheckPeriod          types.Int64    `tfsdk:"check_period"`
+	AutoProcessing       types.Bool     `tfsdk:"auto_processing"`
+	ResponsibleTeam      types.Int64    `tfsdk:"responsible_team"`
+	Timeouts             timeouts.Value `tfsdk:"timeouts"`
+}
+
+func newMonitoringServiceResource() resource.Resource {
+	return &monitoringServiceResource{}
+}
+
+func (r *monitoringServiceResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_monitoring_service"
+}
+
+// Optional arguments are also computed: when they are left out of the
+// configuration, the value set by RTMS, often from the template, is kept
+// instead of showing a diff.
+func (r *monitoringServiceResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = monitoringServiceSchema(ctx)
+}
+
+func monitoringServiceSchema(ctx context.Context) schema.Schema {
+	return schema.Schema{
+		Version: 1,
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"appliance": schema.Int64Attribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "Required unless set in the defaults block of the provider",
+			},
+			"host": schema.Int64Attribute{
+				Required: true,
+			},
+			"name": schema.StringAttribute{
+				Required: true,
+			},
+			"template": schema.Int64Attribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "Required unless set in the defaults block of the provider",
+			},
+			"description":             optionalString(),
+			"max_check_attempts":      optionalInt64(int64validator.AtLeast(1)),
+			"plugin":                  optionalInt64(),
+			"plugin_args":             optionalString(),
+			"is_monitored":            optionalBool(),
+			"notifications_enabled":   optionalBool(),
+			"nice_name":               optionalString(),
+			"keywords":                optionalString(),
+			"help":                    optionalString(),
+			"severity":                optionalInt64(),
+			"only_notify_if_critical": optionalBool(),
+			"normal_check_interval":   optionalInt64(int64validator.AtLeast(1)),
+			"retry_check_interval":    optionalInt64(int64validator.AtLeast(1)),
+			"time_period":             optionalInt64(),
+			"check_period":            optionalInt64(),
+			"ticket_catalogs_items": schema.SetAttribute{
+				ElementType: types.Int64Type,
+				Optional:    true,
+				Computed:    true,
+				Description: "The ticket catalog items of the typology, in any order",
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"auto_processing":  optionalBool(),
+			"responsible_team": optionalInt64(),
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+// The helpers below return optional arguments that keep their previous value
+// when they are left out of the configuration.
+
+func optionalString() schema.StringAttribute {
+	return schema.StringAttribute{
+		Optional: true,
+		Computed: true,
+		PlanModifiers: []planmodifier.String{
+			stringplanmodifier.UseStateForUnknown(),
+		},
+	}
+}
+
+func optionalInt64(validators ...validator.Int64) schema.Int64Attribute {
+	return schema.Int64Attribute{
+		Optional: true,
+		Computed: true,
+		PlanModifiers: []planmodifier.Int64{
+			int64planmodifier.UseStateForUnknown(),
+		},
+		Validators: validators,
+	}
+}
+
+func optionalBool() schema.BoolAttribute {
+	return schema.BoolAttribute{
+		Optional: true,
+		Computed: true,
+		PlanModifiers: []planmodifier.Bool{
+			boolplanmodifier.UseStateForUnknown(),
+		},
+	}
+}
+
+// UpgradeState migrates the state of schema version 0, where
+// ticket_catalogs_items was an ordered list.
+func (r *monitoringServiceResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	priorSchema := monitoringServiceSchema(ctx)
+	priorSchema.Version = 0
+	priorSchema.Attributes = maps.Clone(priorSchema.Attributes)
+	priorSchema.Attributes["ticket_catalogs_items"] = schema.ListAttribute{
+		ElementType: types.Int64Type,
+		Optional:    true,
+		Computed:    true,
+	}
+
+	return map[int64]resource.StateUpgrader{
+		0: {
+			PriorSchema:   &priorSchema,
+			StateUpgrader: upgradeMonitoringServiceStateV0,
+		},
+	}
+}
+
+func upgradeMonitoringServiceStateV0(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+	var prior monitoringServiceResourceModelV0
+	resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state := monitoringServiceResourceModel{
+		monitoringServiceAttributes: prior.monitoringServiceAttributes,
+		TicketCatalogsItems:         types.SetNull(types.Int64Type),
+	}
+	if !prior.TicketCatalogsItems.IsNull() {
+		var items []int64
+		resp.Diagnostics.Append(prior.TicketCatalogsItems.ElementsAs(ctx, &items, false)...)
+		ids := make([]rtms.ID, 0, len(items))
+		for _, item := range items {
+			ids = append(ids, rtms.ID(item))
+		}
+		state.TicketCatalogsItems = idSet(ids)
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// ValidateConfig checks the constraints between arguments, so they fail at
+// plan time rather than with an API error halfway through an apply.
+func (r *monitoringServiceResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config monitoringServiceResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	normal, retry := config.NormalCheckInterval, config.RetryCheckInterval
+	if normal.IsNull() || normal.IsUnknown() || retry.IsNull() || retry.IsUnknown() {
+		return
+	}
+	if retry.ValueInt64() > normal.ValueInt64() {
+		resp.Diagnostics.AddAttributeError(path.Root("retry_check_interval"), "Invalid retry_check_interval",
+			fmt.Sprintf("retry_check_interval (%d) must not be greater than normal_check_interval (%d).", retry.ValueInt64(), normal.ValueInt64()))
+	}
+}
+
+func (r *monitoringServiceResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if data := providerDataFrom(req.ProviderData, &resp.Diagnostics); data != nil {
+		r.client = data.client
+		r.defaults = data.defaults
+	}
+}
+
+// ModifyPlan fills the arguments left out of the configuration from the
+// provider defaults. appliance and template must then be set.
+func (r *monitoringServiceResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	// The provider is not configured yet when the configuration is only
+	// validated, so the required ones cannot be checked by ValidateConfig.
+	for _, d := range []struct {
+		attribute string
+		value     types.Int64
+		required  bool
+	}{
+		{"appliance", r.defaults.Appliance, true},
+		{"template", r.defaults.Template, true},
+		{"time_period", r.defaults.TimePeriod, false},
+		{"check_period", r.defaults.CheckPeriod, false},
+		{"responsible_team", r.defaults.ResponsibleTeam, false},
+	} {
+		if d.required && d.value.IsNull() {
+			var configured types.Int64
+			resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root(d.attribute), &configured)...)
+			if configured.IsNull() {
+				resp.Diagnostics.AddAttributeError(path.Root(d.attribute), fmt.Sprintf("Missing %s", d.attribute),
+					fmt.Sprintf("Set %s in the resource or in the defaults block of the provider.", d.attribute))
+			}
+			continue
+		}
+		planDefault(ctx, req.Config, &resp.Plan, &resp.Diagnostics, d.attribute, d.value)
+	}
+}
+
+func (r *monitoringServiceResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan monitoringServiceResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	timeout, diags := plan.Timeouts.Create(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	// Unset arguments are left out of the request, explicit false and 0
+	// values are sent.
+	service := &rtms.MonitoringServiceInput{
+		Appliance:            intPointer(plan.Appliance),
+		Host:                 intPointer(plan.Host),
+		Name:                 stringPointer(plan.Name),
+		Template:             intPointer(plan.Template),
+		Description:          stringPointer(plan.Description),
+		MaxCheckAttempts:     intPointer(plan.MaxCheckAttempts),
+		Plugin:               intPointer(plan.Plugin),
+		PluginArgs:           stringPointer(plan.PluginArgs),
+		IsMonitored:          boolPointer(plan.IsMonitored),
+		NotificationsEnabled: boolPointer(plan.NotificationsEnabled),
+		NiceName:             stringPointer(plan.NiceName),
+		Keywords:             stringPointer(plan.Keywords),
+		Help:                 stringPointer(plan.Help),
+		Severity:             intPointer(plan.Severity),
+		OnlyNotifyIfCritical: boolPointer(plan.OnlyNotifyIfCritical),
+		NormalCheckInterval:  intPointer(plan.NormalCheckInterval),
+		RetryCheckInterval:   intPointer(plan.RetryCheckInterval),
+		TimePeriod:           intPointer(plan.TimePeriod),
+		CheckPeriod:          intPointer(plan.CheckPeriod),
+		TicketCatalogsItems:  intsPointer(ctx, plan.TicketCatalogsItems, &resp.Diagnostics),
+		AutoProcessing:       boolPointer(plan.AutoProcessing),
+		ResponsibleTeam:      intPointer(plan.ResponsibleTeam),
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	serviceID, err := r.client.CreateMonitoringService(ctx, service)
+	if err != nil {
+		resp.Diagnostics.Append(diagFromErrAt("Unable to create monitoring service", path.Empty(), monitoringServiceSchema(ctx).Attributes, err)...)
+		return
+	}
+
+	// Record the id right away so the service is tracked even if reading it
+	// back fails.
+	plan.ID = types.StringValue(strconv.Itoa(serviceID))
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), plan.ID)...)
+
+	created, err := r.client.GetMonitoringService(ctx, serviceID)
+	if err != nil {
+		resp.Diagnostics.Append(diagFromErr("Unable to read monitoring service", err)...)
+		return
+	}
+
+	setMonitoringServiceModel(&plan, created)
+	if plan.PluginArgs.IsUnknown() {
+		plan.PluginArgs = types.StringNull()
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *monitoringServiceResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state monitoringServiceResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	timeout, diags := state.Timeouts.Read(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	id, err := strconv.Atoi(state.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid monitoring service id", fmt.Sprintf("invalid monitoring service id %q: %s", state.ID.ValueString(), err))
+		return
+	}
+
+	service, err := r.client.GetMonitoringService(ctx, id)
+	if rtms.IsNotFound(err) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.Append(diagFromErr("Unable to read monitoring service", err)...)
+		return
+	}
+
+	setMonitoringServiceModel(&state, service)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// setMonitoringServiceModel copies service to the attributes read back from
+// the API. The plugin arguments are not returned and keep their configured
+// value.
+func setMonitoringServiceModel(m *monitoringServiceResourceModel, service *rtms.MonitoringService) {
+	m.Name = types.StringValue(service.Name)
+	m.Description = types.StringValue(service.Description)
+	if service.Host != nil {
+		m.Host = types.Int64Value(int64(service.Host.ID))
+	}
+	if service.Template != nil {
+		m.Template = types.Int64Value(int64(service.Template.ID))
+	}
+	if service.Appliance != nil {
+		m.Appliance = types.Int64Value(int64(service.Appliance.ID))
+	}
+	m.Plugin = types.Int64Null()
+	if service.Plugin != nil {
+		m.Plugin = types.Int64Value(int64(service.Plugin.ID))
+	}
+	m.IsMonitored = types.BoolValue(service.IsMonitored)
+	m.NotificationsEnabled = types.BoolValue(service.NotificationsEnabled)
+	m.NiceName = types.StringValue(service.NiceName)
+	m.Keywords = types.StringValue(service.Keywords)
+	m.Help = types.StringValue(service.Help)
+	m.Severity = types.Int64Value(int64(service.Severity))
+	m.OnlyNotifyIfCritical = types.BoolValue(service.OnlyNotifyIfCritical)
+	m.NormalCheckInterval = types.Int64Value(int64(service.NormalCheckInterval))
+	m.RetryCheckInterval = types.Int64Value(int64(service.RetryCheckInterval))
+	m.MaxCheckAttempts = types.Int64Value(int64(service.MaxCheckAttempts))
+	m.TimePeriod = types.Int64Null()
+	if service.TimePeriod != nil {
+		m.TimePeriod = types.Int64Value(int64(service.TimePeriod.ID))
+	}
+	m.CheckPeriod = types.Int64Null()
+	if service.CheckPeriod != nil {
+		m.CheckPeriod = types.Int64Value(int64(service.CheckPeriod.ID))
+	}
+	m.TicketCatalogsItems = ticketCatalogsItems(service.TicketCatalogsItems)
+	m.AutoProcessing = types.BoolValue(service.AutoProcessing)
+	m.ResponsibleTeam = types.Int64Null()
+	if service.ResponsibleTeam != nil {
+		m.ResponsibleTeam = types.Int64Value(int64(service.ResponsibleTeam.ID))
+	}
+}
+
+func (r *monitoringServiceResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state monitoringServiceResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	timeout, diags := plan.Timeouts.Update(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	id, err := strconv.Atoi(state.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid monitoring service id", fmt.Sprintf("invalid monitoring service id %q: %s", state.ID.ValueString(), err))
+		return
+	}
+
+	service := &rtms.MonitoringServiceInput{}
+
+	if changed(plan.Appliance, state.Appliance) {
+		service.Appliance = intPointer(plan.Appliance)
+	}
+	if changed(plan.Host, state.Host) {
+		service.Host = intPointer(plan.Host)
+	}
+	if changed(plan.Name, state.Name) {
+		service.Name = stringPointer(plan.Name)
+	}
+	if changed(plan.Template, state.Template) {
+		service.Template = intPointer(plan.Template)
+	}
+	if changed(plan.Description, state.Description) {
+		service.Description = stringPointer(plan.Description)
+	}
+	if changed(plan.MaxCheckAttempts, state.MaxCheckAttempts) {
+		service.MaxCheckAttempts = intPointer(plan.MaxCheckAttempts)
+	}
+	if changed(plan.Plugin, state.Plugin) {
+		service.Plugin = intPointer(plan.Plugin)
+	}
+	if changed(plan.PluginArgs, state.PluginArgs) {
+		service.PluginArgs = stringPointer(plan.PluginArgs)
+	}
+	if changed(plan.IsMonitored, state.IsMonitored) {
+		service.IsMonitored = boolPointer(plan.IsMonitored)
+	}
+	if changed(plan.NotificationsEnabled, state.NotificationsEnabled) {
+		service.NotificationsEnabled = boolPointer(plan.NotificationsEnabled)
+	}
+	if changed(plan.NiceName, state.NiceName) {
+		service.NiceName = stringPointer(plan.NiceName)
+	}
+	if changed(plan.Keywords, state.Keywords) {
+		service.Keywords = stringPointer(plan.Keywords)
+	}
+	if changed(plan.Help, state.Help) {
+		service.Help = stringPointer(plan.Help)
+	}
+	if changed(plan.Severity, state.Severity) {
+		service.Severity = intPointer(plan.Severity)
+	}
+	if changed(plan.OnlyNotifyIfCritical, state.OnlyNotifyIfCritical) {
+		service.OnlyNotifyIfCritical = boolPointer(plan.OnlyNotifyIfCritical)
+	}
+	if changed(plan.NormalCheckInterval, state.NormalCheckInterval) {
+		service.NormalCheckInterval = intPointer(plan.NormalCheckInterval)
+	}
+	if changed(plan.RetryCheckInterval, state.RetryCheckInterval) {
+		service.RetryCheckInterval = intPointer(plan.RetryCheckInterval)
+	}
+	if changed(plan.TimePeriod, state.TimePeriod) {
+		service.TimePeriod = intPointer(plan.TimePeriod)
+	}
+	if changed(plan.CheckPeriod, state.CheckPeriod) {
+		service.CheckPeriod = intPointer(plan.CheckPeriod)
+	}
+	if changed(plan.TicketCatalogsItems, state.TicketCatalogsItems) {
+		service.TicketCatalogsItems = intsPointer(ctx, plan.TicketCatalogsItems, &resp.Diagnostics)
+	}
+	if changed(plan.AutoProcessing, state.AutoProcessing) {
+		service.AutoProcessing = boolPointer(plan.AutoProcessing)
+	}
+	if changed(plan.ResponsibleTeam, state.ResponsibleTeam) {
+		service.ResponsibleTeam = intPointer(plan.ResponsibleTeam)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.client.PatchMonitoringService(ctx, id, service); err != nil {
+		resp.Diagnostics.Append(diagFromErrAt("Unable to update monitoring service", path.Empty(), monitoringServiceSchema(ctx).Attributes, err)...)
+		return
+	}
+
+	updated, err := r.client.GetMonitoringService(ctx, id)
+	if err != nil {
+		resp.Diagnostics.Append(diagFromErr("Unable to read monitoring service", err)...)
+		return
+	}
+
+	setMonitoringServiceModel(&plan, updated)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *monitoringServiceResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state monitoringServiceResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	timeout, diags := state.Timeouts.Delete(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	id, err := strconv.Atoi(state.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid monitoring service id", fmt.Sprintf("invalid monitoring service id %q: %s", state.ID.ValueString(), err))
+		return
+	}
+
+	// A monitoring service already deleted outside of Terraform is not an error.
+	if err := r.client.DeleteMonitoringService(ctx, id); err != nil && !rtms.IsNotFound(err) {
+		resp.Diagnostics.Append(diagFromErr("Unable to delete monitoring service", err)...)
+	}
+}
+
+// ImportState accepts either a numeric service id or
+// "<host name>/<service name>".
+func (r *monitoringServiceResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if _, err := strconv.Atoi(req.ID); err == nil {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+		return
+	}
+
+	hostName, serviceName, ok := strings.Cut(req.ID, "/")
+	if !ok {
+		resp.Diagnostics.AddError("Invalid import id",
+			fmt.Sprintf("invalid import id %q, expected a numeric service id or <host name>/<service name>", req.ID))
+		return
+	}
+
+	host, err := findHostByName(ctx, r.client, hostName)
+	if err != nil {
+		resp.Diagnostics.Append(diagFromErr("Unable to find host", err)...)
+		return
+	}
+
+	services, err := r.client.ListMonitoringServices(ctx, &rtms.MonitoringServiceListOptions{Host: int(host.ID)})
+	if err != nil {
+		resp.Diagnostics.Append(diagFromErr("Unable to list monitoring services", err)...)
+		return
+	}
+
+	service, err := findOne("monitoring service", services, func(s rtms.MonitoringService) bool {
+		return s.Host != nil && s.Host.ID == host.ID && s.Name == serviceName
+	}, fmt.Sprintf("name %q on host %q", serviceName, hostName))
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to find monitoring service", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), service.ID.String())...)
+}
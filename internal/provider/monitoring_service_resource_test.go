@@ -0,0 +1,270 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"testing"
+
+	"github.com/Bithault/terraform-provider-rtms/rtms"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestAccMonitoringServiceResource(t *testing.T) {
+	name := acctest.RandomWithPrefix(testAccNamePrefix)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckMonitoringServiceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMonitoringServiceConfig(name, "Acceptance test", 3),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckMonitoringServiceRemote("rtms_monitoring_service.test", "Acceptance test", 3),
+					resource.TestCheckResourceAttr("rtms_monitoring_service.test", "name", "PING"),
+					resource.TestCheckResourceAttr("rtms_monitoring_service.test", "description", "Acceptance test"),
+					resource.TestCheckResourceAttr("rtms_monitoring_service.test", "max_check_attempts", "3"),
+					resource.TestCheckResourceAttr("rtms_monitoring_service.test", "plugin_args", "-w 100,20% -c 500,60%"),
+					resource.TestCheckResourceAttrPair("rtms_monitoring_service.test", "host", "rtms_host.test", "id"),
+					resource.TestCheckResourceAttrPair("rtms_monitoring_service.test", "template", "data.rtms_template.test", "id"),
+					resource.TestCheckResourceAttrPair("rtms_monitoring_service.test", "plugin", "data.rtms_plugin.test", "id"),
+					resource.TestCheckResourceAttrSet("rtms_monitoring_service.test", "severity"),
+					resource.TestCheckResourceAttrSet("rtms_monitoring_service.test", "normal_check_interval"),
+				),
+			},
+			{
+				ResourceName:      "rtms_monitoring_service.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+				// The API does not return the plugin arguments.
+				ImportStateVerifyIgnore: []string{"plugin_args"},
+			},
+			{
+				ResourceName:            "rtms_monitoring_service.test",
+				ImportState:             true,
+				ImportStateId:           name + "/PING",
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"plugin_args"},
+			},
+			{
+				Config: testAccMonitoringServiceConfig(name, "Acceptance test, updated", 5),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckMonitoringServiceRemote("rtms_monitoring_service.test", "Acceptance test, updated", 5),
+					resource.TestCheckResourceAttr("rtms_monitoring_service.test", "description", "Acceptance test, updated"),
+					resource.TestCheckResourceAttr("rtms_monitoring_service.test", "max_check_attempts", "5"),
+				),
+			},
+		},
+	})
+}
+
+// TestAccMonitoringServiceResource_disappears checks that a service deleted
+// outside of Terraform is removed from the state instead of failing the
+// refresh.
+func TestAccMonitoringServiceResource_disappears(t *testing.T) {
+	name := acctest.RandomWithPrefix(testAccNamePrefix)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckMonitoringServiceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMonitoringServiceConfig(name, "Acceptance test", 3),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMonitoringServiceRemote("rtms_monitoring_service.test", "Acceptance test", 3),
+					testAccCheckMonitoringServiceDisappears("rtms_monitoring_service.test"),
+				),
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+// TestAccMonitoringServiceResource_invalidConfig checks that out of range
+// counts and intervals fail at plan time.
+func TestAccMonitoringServiceResource_invalidConfig(t *testing.T) {
+	var steps []resource.TestStep
+	for _, tt := range []struct {
+		attributes string
+		err        string
+	}{
+		{"max_check_attempts = 0", `max_check_attempts value must be at least 1, got: 0`},
+		{"normal_check_interval = 0", `normal_check_interval value must be at least 1, got: 0`},
+		{"retry_check_interval = -1", `retry_check_interval value must be at least 1, got: -1`},
+		{"normal_check_interval = 5\n  retry_check_interval = 10", `retry_check_interval \(10\) must not be greater than\s+normal_check_interval \(5\)`},
+	} {
+		steps = append(steps, resource.TestStep{
+			Config:      testAccMonitoringServiceIntervalsConfig(tt.attributes),
+			ExpectError: regexp.MustCompile(tt.err),
+		})
+	}
+	steps = append(steps, resource.TestStep{
+		Config:             testAccMonitoringServiceIntervalsConfig("max_check_attempts = 1\n  normal_check_interval = 5\n  retry_check_interval = 5"),
+		PlanOnly:           true,
+		ExpectNonEmptyPlan: true,
+	})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps:                    steps,
+	})
+}
+
+// TestMonitoringServiceUpgradeStateV0 checks the migration of the state of
+// schema version 0, where ticket_catalogs_items was an ordered list.
+func TestMonitoringServiceUpgradeStateV0(t *testing.T) {
+	tests := []struct {
+		name  string
+		items string
+		want  types.Set
+	}{
+		{"list", "[3, 1, 2, 1]", idSet([]rtms.ID{1, 2, 3})},
+		{"empty list", "[]", idSet(nil)},
+		{"null", "null", types.SetNull(types.Int64Type)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			upgraded := testUpgradeState(t, newMonitoringServiceResource(), 0, fmt.Sprintf(`{
+  "id": "12",
+  "appliance": 1,
+  "host": 3,
+  "name": "PING",
+  "template": 1,
+  "description": "Ping",
+  "max_check_attempts": 3,
+  "plugin": 2,
+  "plugin_args": "-w 100,20%%",
+  "is_monitored": true,
+  "notifications_enabled": false,
+  "nice_name": "",
+  "keywords": "",
+  "help": "",
+  "severity": 3,
+  "only_notify_if_critical": false,
+  "normal_check_interval": 5,
+  "retry_check_interval": 1,
+  "time_period": 1,
+  "check_period": 2,
+  "ticket_catalogs_items": %s,
+  "auto_processing": false,
+  "responsible_team": 4
+}`, tt.items))
+
+			var state monitoringServiceResourceModel
+			if diags := upgraded.Get(context.Background(), &state); diags.HasError() {
+				t.Fatalf("reading the upgraded state: %v", diags)
+			}
+			if !state.TicketCatalogsItems.Equal(tt.want) {
+				t.Errorf("ticket_catalogs_items = %s, want %s", state.TicketCatalogsItems, tt.want)
+			}
+			for _, attr := range []struct {
+				name      string
+				got, want attr.Value
+			}{
+				{"id", state.ID, types.StringValue("12")},
+				{"host", state.Host, types.Int64Value(3)},
+				{"name", state.Name, types.StringValue("PING")},
+				{"plugin_args", state.PluginArgs, types.StringValue("-w 100,20%")},
+				{"notifications_enabled", state.NotificationsEnabled, types.BoolValue(false)},
+				{"responsible_team", state.ResponsibleTeam, types.Int64Value(4)},
+			} {
+				if !attr.got.Equal(attr.want) {
+					t.Errorf("%s = %s, want %s", attr.name, attr.got, attr.want)
+				}
+			}
+		})
+	}
+}
+
+func testAccMonitoringServiceConfig(hostName, description string, maxCheckAttempts int) string {
+	return testAccCatalogConfig() + fmt.Sprintf(`
+resource "rtms_host" "test" {
+  name      = %q
+  alias     = "Acceptance test"
+  address   = "192.0.2.20"
+  appliance = data.rtms_appliance.test.id
+}
+
+resource "rtms_monitoring_service" "test" {
+  appliance          = data.rtms_appliance.test.id
+  host               = rtms_host.test.id
+  name               = "PING"
+  template           = data.rtms_template.test.id
+  plugin             = data.rtms_plugin.test.id
+  plugin_args        = "-w 100,20%% -c 500,60%%"
+  description        = %q
+  max_check_attempts = %d
+}
+`, hostName, description, maxCheckAttempts)
+}
+
+func testAccMonitoringServiceIntervalsConfig(attributes string) string {
+	return fmt.Sprintf(`
+resource "rtms_monitoring_service" "test" {
+  appliance = 1
+  host      = 1
+  name      = "PING"
+  template  = 1
+  %s
+}
+`, attributes)
+}
+
+// testAccCheckMonitoringServiceRemote checks the service as returned by the
+// API.
+func testAccCheckMonitoringServiceRemote(address, description string, maxCheckAttempts int) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		id, err := testAccResourceID(s, address)
+		if err != nil {
+			return err
+		}
+		service, err := testAccClient().GetMonitoringService(context.Background(), id)
+		if err != nil {
+			return err
+		}
+		if service.Description != description || service.MaxCheckAttempts != maxCheckAttempts {
+			return fmt.Errorf("service %d has description %q and max_check_attempts %d, want %q and %d",
+				id, service.Description, service.MaxCheckAttempts, description, maxCheckAttempts)
+		}
+		return nil
+	}
+}
+
+func testAccCheckMonitoringServiceDisappears(address string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		id, err := testAccResourceID(s, address)
+		if err != nil {
+			return err
+		}
+		return testAccClient().DeleteMonitoringService(context.Background(), id)
+	}
+}
+
+func testAccCheckMonitoringServiceDestroy(s *terraform.State) error {
+	client := testAccClient()
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "rtms_monitoring_service" {
+			continue
+		}
+		id, err := strconv.Atoi(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		_, err = client.GetMonitoringService(context.Background(), id)
+		if err == nil {
+			return fmt.Errorf("service %d still exists", id)
+		}
+		if !rtms.IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,294 @@
+package provider
+
+import (
+	"context"
+	"strings"
+
+	"github.com/Bithault/terraform-provider-rtms/rtms"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type monitoringServicesDataSource struct {
+	client *rtms.Client
+}
+
+type monitoringServicesDataSourceModel struct {
+	ID              types.String                               `tfsdk:"id"`
+	Host            types.Int64                                `tfsdk:"host"`
+	Template        types.Int64                                `tfsdk:"template"`
+	Plugin          types.Int64                                `tfsdk:"plugin"`
+	ResponsibleTeam types.Int64                                `tfsdk:"responsible_team"`
+	Keyword         types.String                               `tfsdk:"keyword"`
+	Services        []monitoringServicesDataSourceServiceModel `tfsdk:"services"`
+}
+
+type monitoringServicesDataSourceServiceModel struct {
+	ID                   types.Int64  `tfsdk:"id"`
+	Name                 types.String `tfsdk:"name"`
+	Description          types.String `tfsdk:"description"`
+	Host                 types.Int64  `tfsdk:"host"`
+	Appliance            types.Int64  `tfsdk:"appliance"`
+	Template             types.Int64  `tfsdk:"template"`
+	Plugin               types.Int64  `tfsdk:"plugin"`
+	PluginIsDeprecated   types.Bool   `tfsdk:"plugin_is_deprecated"`
+	IsMonitored          types.Bool   `tfsdk:"is_monitored"`
+	NotificationsEnabled types.Bool   `tfsdk:"notifications_enabled"`
+	NiceName             types.String `tfsdk:"nice_name"`
+	Keywords             types.String `tfsdk:"keywords"`
+	Severity             types.Int64  `tfsdk:"severity"`
+	OnlyNotifyIfCritical types.Bool   `tfsdk:"only_notify_if_critical"`
+	NormalCheckInterval  types.Int64  `tfsdk:"normal_check_interval"`
+	RetryCheckInterval   types.Int64  `tfsdk:"retry_check_interval"`
+	MaxCheckAttempts     types.Int64  `tfsdk:"max_check_attempts"`
+	TimePeriod           types.Int64  `tfsdk:"time_period"`
+	CheckPeriod          types.Int64  `tfsdk:"check_period"`
+	TicketCatalogsItems  types.Set    `tfsdk:"ticket_catalogs_items"`
+	AutoProcessing       types.Bool   `tfsdk:"auto_processing"`
+	ResponsibleTeam      types.Int64  `tfsdk:"responsible_team"`
+	Status               types.String `tfsdk:"status"`
+	Output               types.String `tfsdk:"output"`
+	LastCheck            types.String `tfsdk:"last_check"`
+}
+
+func newMonitoringServicesDataSource() datasource.DataSource {
+	return &monitoringServicesDataSource{}
+}
+
+func (d *monitoringServicesDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_monitoring_services"
+}
+
+func (d *monitoringServicesDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+			},
+			"host": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Only return the services of this host",
+			},
+			"template": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Only return the services using this template",
+			},
+			"plugin": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Only return the services using this plugin",
+			},
+			"responsible_team": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Only return the services this team is responsible for",
+			},
+			"keyword": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only return the services tagged with this keyword",
+			},
+			"services": schema.ListNestedAttribute{
+				Computed: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.Int64Attribute{
+							Computed: true,
+						},
+						"name": schema.StringAttribute{
+							Computed: true,
+						},
+						"description": schema.StringAttribute{
+							Computed: true,
+						},
+						"host": schema.Int64Attribute{
+							Computed: true,
+						},
+						"appliance": schema.Int64Attribute{
+							Computed: true,
+						},
+						"template": schema.Int64Attribute{
+							Computed: true,
+						},
+						"plugin": schema.Int64Attribute{
+							Computed: true,
+						},
+						"plugin_is_deprecated": schema.BoolAttribute{
+							Computed: true,
+						},
+						"is_monitored": schema.BoolAttribute{
+							Computed: true,
+						},
+						"notifications_enabled": schema.BoolAttribute{
+							Computed: true,
+						},
+						"nice_name": schema.StringAttribute{
+							Computed: true,
+						},
+						"keywords": schema.StringAttribute{
+							Computed: true,
+						},
+						"severity": schema.Int64Attribute{
+							Computed: true,
+						},
+						"only_notify_if_critical": schema.BoolAttribute{
+							Computed: true,
+						},
+						"normal_check_interval": schema.Int64Attribute{
+							Computed: true,
+						},
+						"retry_check_interval": schema.Int64Attribute{
+							Computed: true,
+						},
+						"max_check_attempts": schema.Int64Attribute{
+							Computed: true,
+						},
+						"time_period": schema.Int64Attribute{
+							Computed: true,
+						},
+						"check_period": schema.Int64Attribute{
+							Computed: true,
+						},
+						"ticket_catalogs_items": schema.SetAttribute{
+							ElementType: types.Int64Type,
+							Computed:    true,
+						},
+						"auto_processing": schema.BoolAttribute{
+							Computed: true,
+						},
+						"responsible_team": schema.Int64Attribute{
+							Computed: true,
+						},
+						"status": schema.StringAttribute{
+							Computed:    true,
+							Description: "Result of the latest check: OK, WARNING, CRITICAL or UNKNOWN",
+						},
+						"output": schema.StringAttribute{
+							Computed:    true,
+							Description: "Output of the latest check",
+						},
+						"last_check": schema.StringAttribute{
+							Computed:    true,
+							Description: "Time of the latest check",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *monitoringServicesDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	d.client = clientFrom(req.ProviderData, &resp.Diagnostics)
+}
+
+func (d *monitoringServicesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data monitoringServicesDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	opts := &rtms.MonitoringServiceListOptions{
+		Host:            int(data.Host.ValueInt64()),
+		Template:        int(data.Template.ValueInt64()),
+		Plugin:          int(data.Plugin.ValueInt64()),
+		ResponsibleTeam: int(data.ResponsibleTeam.ValueInt64()),
+		Keyword:         data.Keyword.ValueString(),
+	}
+
+	services, err := d.client.ListMonitoringServices(ctx, opts)
+	if err != nil {
+		resp.Diagnostics.Append(diagFromErr("Unable to list monitoring services", err)...)
+		return
+	}
+
+	data.Services = make([]monitoringServicesDataSourceServiceModel, 0, len(services))
+	for _, service := range services {
+		s := monitoringServicesDataSourceServiceModel{
+			ID:                   types.Int64Value(int64(service.ID)),
+			Name:                 types.StringValue(service.Name),
+			Description:          types.StringValue(service.Description),
+			IsMonitored:          types.BoolValue(service.IsMonitored),
+			NotificationsEnabled: types.BoolValue(service.NotificationsEnabled),
+			NiceName:             types.StringValue(service.NiceName),
+			Keywords:             types.StringValue(service.Keywords),
+			Severity:             types.Int64Value(int64(service.Severity)),
+			OnlyNotifyIfCritical: types.BoolValue(service.OnlyNotifyIfCritical),
+			NormalCheckInterval:  types.Int64Value(int64(service.NormalCheckInterval)),
+			RetryCheckInterval:   types.Int64Value(int64(service.RetryCheckInterval)),
+			MaxCheckAttempts:     types.Int64Value(int64(service.MaxCheckAttempts)),
+			AutoProcessing:       types.BoolValue(service.AutoProcessing),
+			TicketCatalogsItems:  ticketCatalogsItems(service.TicketCatalogsItems),
+		}
+		if service.Host != nil {
+			s.Host = types.Int64Value(int64(service.Host.ID))
+		}
+		s.Appliance = applianceID(service.Appliance)
+		if service.Template != nil {
+			s.Template = types.Int64Value(int64(service.Template.ID))
+		}
+		if service.Plugin != nil {
+			s.Plugin = types.Int64Value(int64(service.Plugin.ID))
+			s.PluginIsDeprecated = types.BoolValue(service.Plugin.IsDeprecated)
+		}
+		if service.TimePeriod != nil {
+			s.TimePeriod = types.Int64Value(int64(service.TimePeriod.ID))
+		}
+		if service.CheckPeriod != nil {
+			s.CheckPeriod = types.Int64Value(int64(service.CheckPeriod.ID))
+		}
+		if service.ResponsibleTeam != nil {
+			s.ResponsibleTeam = types.Int64Value(int64(service.ResponsibleTeam.ID))
+		}
+		if service.State != nil {
+			s.Status = types.StringValue(service.State.Status)
+			s.Output = types.StringValue(service.State.Output)
+			s.LastCheck = types.StringValue(service.State.LastCheck)
+		}
+
+		// The filters are also sent to the API, they are checked again here
+		// in case the endpoint ignores them.
+		if !matchesServiceFilters(s, opts) {
+			continue
+		}
+
+		data.Services = append(data.Services, s)
+	}
+
+	data.ID = types.StringValue(d.client.CloudTempleID())
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func matchesServiceFilters(s monitoringServicesDataSourceServiceModel, opts *rtms.MonitoringServiceListOptions) bool {
+	for _, filter := range []struct {
+		got  types.Int64
+		want int
+	}{
+		{s.Host, opts.Host},
+		{s.Template, opts.Template},
+		{s.Plugin, opts.Plugin},
+		{s.ResponsibleTeam, opts.ResponsibleTeam},
+	} {
+		if filter.want != 0 && filter.got.ValueInt64() != int64(filter.want) {
+			return false
+		}
+	}
+
+	if opts.Keyword == "" {
+		return true
+	}
+	for _, keyword := range strings.Split(s.Keywords.ValueString(), ",") {
+		if strings.TrimSpace(keyword) == opts.Keyword {
+			return true
+		}
+	}
+	return false
+}
+
+// ticketCatalogsItems returns the ids of the typology of a service.
+func ticketCatalogsItems(items []rtms.TicketCatalogItem) types.Set {
+	ids := make([]rtms.ID, 0, len(items))
+	for _, item := range items {
+		ids = append(ids, item.ID)
+	}
+	return idSet(ids)
+}
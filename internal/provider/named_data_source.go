@@ -0,0 +1,139 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/Bithault/terraform-provider-rtms/rtms"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// namedDataSource implements the data sources of RTMS objects that only have
+// an id and a name, either of which can be used for the lookup.
+type namedDataSource struct {
+	typeName string
+	kind     string
+	list     func(ctx context.Context, client *rtms.Client) ([]rtms.Ref, error)
+	client   *rtms.Client
+}
+
+type namedDataSourceModel struct {
+	ID   types.Int64  `tfsdk:"id"`
+	Name types.String `tfsdk:"name"`
+}
+
+func newTemplateDataSource() datasource.DataSource {
+	return &namedDataSource{
+		typeName: "template",
+		kind:     "template",
+		list: func(ctx context.Context, client *rtms.Client) ([]rtms.Ref, error) {
+			return refs(client.ListTemplates(ctx))
+		},
+	}
+}
+
+func newTeamDataSource() datasource.DataSource {
+	return &namedDataSource{
+		typeName: "team",
+		kind:     "team",
+		list: func(ctx context.Context, client *rtms.Client) ([]rtms.Ref, error) {
+			return refs(client.ListTeams(ctx))
+		},
+	}
+}
+
+// Check periods and time periods are the same RTMS objects, so both data
+// sources list the same endpoint.
+
+func newCheckPeriodDataSource() datasource.DataSource {
+	return &namedDataSource{
+		typeName: "checkperiod",
+		kind:     "time period",
+		list: func(ctx context.Context, client *rtms.Client) ([]rtms.Ref, error) {
+			return refs(client.ListTimePeriods(ctx))
+		},
+	}
+}
+
+func newTimePeriodDataSource() datasource.DataSource {
+	return &namedDataSource{
+		typeName: "timeperiod",
+		kind:     "time period",
+		list: func(ctx context.Context, client *rtms.Client) ([]rtms.Ref, error) {
+			return refs(client.ListTimePeriods(ctx))
+		},
+	}
+}
+
+// refs converts objects made of an id and a name to references.
+func refs[T rtms.Template | rtms.Team | rtms.TimePeriod](items []T, err error) ([]rtms.Ref, error) {
+	if err != nil {
+		return nil, err
+	}
+	result := make([]rtms.Ref, 0, len(items))
+	for _, item := range items {
+		result = append(result, rtms.Ref(item))
+	}
+	return result, nil
+}
+
+func (d *namedDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_" + d.typeName
+}
+
+func (d *namedDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: idOrNameAttributes(),
+	}
+}
+
+// idOrNameAttributes returns the id and name attributes of the data sources
+// looking an object up by either of them. Both can be set, as with the former
+// SDK based provider, if they refer to the same object.
+func idOrNameAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"id": schema.Int64Attribute{
+			Optional: true,
+			Computed: true,
+			Validators: []validator.Int64{
+				int64validator.AtLeastOneOf(path.MatchRoot("name")),
+			},
+		},
+		"name": schema.StringAttribute{
+			Optional: true,
+			Computed: true,
+		},
+	}
+}
+
+func (d *namedDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	d.client = clientFrom(req.ProviderData, &resp.Diagnostics)
+}
+
+func (d *namedDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data namedDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	items, err := d.list(ctx, d.client)
+	if err != nil {
+		resp.Diagnostics.Append(diagFromErr("Unable to list "+d.kind+"s", err)...)
+		return
+	}
+
+	item, err := findByIDOrName(data.ID, data.Name, d.kind, items, func(r rtms.Ref) (int, string) { return int(r.ID), r.Name })
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to find "+d.kind, err.Error())
+		return
+	}
+
+	data.ID = types.Int64Value(int64(item.ID))
+	data.Name = types.StringValue(item.Name)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
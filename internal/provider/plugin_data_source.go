@@ -0,0 +1,67 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/Bithault/terraform-provider-rtms/rtms"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type pluginDataSource struct {
+	client *rtms.Client
+}
+
+type pluginDataSourceModel struct {
+	ID           types.Int64  `tfsdk:"id"`
+	Name         types.String `tfsdk:"name"`
+	IsDeprecated types.Bool   `tfsdk:"isdeprecated"`
+}
+
+func newPluginDataSource() datasource.DataSource {
+	return &pluginDataSource{}
+}
+
+func (d *pluginDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_plugin"
+}
+
+func (d *pluginDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	attributes := idOrNameAttributes()
+	attributes["isdeprecated"] = schema.BoolAttribute{
+		Computed: true,
+	}
+	resp.Schema = schema.Schema{
+		Attributes: attributes,
+	}
+}
+
+func (d *pluginDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	d.client = clientFrom(req.ProviderData, &resp.Diagnostics)
+}
+
+func (d *pluginDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data pluginDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plugins, err := d.client.ListPlugins(ctx)
+	if err != nil {
+		resp.Diagnostics.Append(diagFromErr("Unable to list plugins", err)...)
+		return
+	}
+
+	plugin, err := findByIDOrName(data.ID, data.Name, "plugin", plugins, func(p rtms.Plugin) (int, string) { return int(p.ID), p.Name })
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to find plugin", err.Error())
+		return
+	}
+
+	data.ID = types.Int64Value(int64(plugin.ID))
+	data.Name = types.StringValue(plugin.Name)
+	data.IsDeprecated = types.BoolValue(plugin.IsDeprecated)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
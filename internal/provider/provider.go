@@ -0,0 +1,205 @@
+// Package provider implements the rtms Terraform provider on top of
+// terraform-plugin-framework.
+package provider
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/Bithault/terraform-provider-rtms/rtms"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const (
+	defaultMaxRetries        = 4
+	defaultRetryMaxWait      = 30
+	defaultRequestsPerSecond = 10
+
+	// defaultTimeout applies to each operation of the resources whose
+	// timeouts block leaves it unset.
+	defaultTimeout = 5 * time.Minute
+)
+
+// New returns a function building the rtms provider, as expected by
+// providerserver.Serve.
+func New(version string) func() provider.Provider {
+	return func() provider.Provider {
+		return &rtmsProvider{version: version}
+	}
+}
+
+type rtmsProvider struct {
+	version string
+}
+
+type rtmsProviderModel struct {
+	AuthToken         types.String           `tfsdk:"auth_token"`
+	CloudTempleID     types.String           `tfsdk:"cloud_temple_id"`
+	Endpoint          types.String           `tfsdk:"endpoint"`
+	MaxRetries        types.Int64            `tfsdk:"max_retries"`
+	RetryMaxWait      types.Int64            `tfsdk:"retry_max_wait"`
+	RequestsPerSecond types.Float64          `tfsdk:"requests_per_second"`
+	Defaults          *providerDefaultsModel `tfsdk:"defaults"`
+}
+
+// providerData is what the provider's Configure method hands to the data
+// sources and resources.
+type providerData struct {
+	client   *rtms.Client
+	defaults providerDefaultsModel
+}
+
+func (p *rtmsProvider) Metadata(_ context.Context, _ provider.MetadataRequest, resp *provider.MetadataResponse) {
+	resp.TypeName = "rtms"
+	resp.Version = p.version
+}
+
+func (p *rtmsProvider) Schema(_ context.Context, _ provider.SchemaRequest, resp *provider.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"auth_token": schema.StringAttribute{
+				Optional:    true,
+				Sensitive:   true,
+				Description: "The X-AUTH-TOKEN for API authentication",
+			},
+			"cloud_temple_id": schema.StringAttribute{
+				Optional:    true,
+				Description: "The cloudTempleId for API calls",
+			},
+			"endpoint": schema.StringAttribute{
+				Optional:    true,
+				Description: "The base URL of the RTMS API",
+			},
+			"max_retries": schema.Int64Attribute{
+				Optional:    true,
+				Description: fmt.Sprintf("How many times a request failing with a transient error is retried. Requests throttled by the API (HTTP 429) do not count against it and are retried up to %d times, even when it is 0", rtms.MaxThrottledRetries),
+			},
+			"retry_max_wait": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Maximum number of seconds to wait between two retries, including the delay asked for by a Retry-After header",
+			},
+			"requests_per_second": schema.Float64Attribute{
+				Optional:    true,
+				Description: "Maximum number of API requests sent per second, 0 to disable the limit",
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"defaults": defaultsBlock(),
+		},
+	}
+}
+
+func (p *rtmsProvider) Configure(ctx context.Context, req provider.ConfigureRequest, resp *provider.ConfigureResponse) {
+	var config rtmsProviderModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	authToken := stringOrEnv(config.AuthToken, "RTMS_AUTH_TOKEN", "")
+	if authToken == "" {
+		resp.Diagnostics.AddAttributeError(path.Root("auth_token"), "Missing RTMS auth token",
+			"Set auth_token in the provider configuration or the RTMS_AUTH_TOKEN environment variable.")
+	}
+	cloudTempleID := stringOrEnv(config.CloudTempleID, "RTMS_CLOUD_TEMPLE_ID", "")
+	if cloudTempleID == "" {
+		resp.Diagnostics.AddAttributeError(path.Root("cloud_temple_id"), "Missing RTMS tenant",
+			"Set cloud_temple_id in the provider configuration or the RTMS_CLOUD_TEMPLE_ID environment variable.")
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	maxRetries := int64(defaultMaxRetries)
+	if !config.MaxRetries.IsNull() {
+		maxRetries = config.MaxRetries.ValueInt64()
+	}
+	retryMaxWait := int64(defaultRetryMaxWait)
+	if !config.RetryMaxWait.IsNull() {
+		retryMaxWait = config.RetryMaxWait.ValueInt64()
+	}
+	requestsPerSecond := float64(defaultRequestsPerSecond)
+	if !config.RequestsPerSecond.IsNull() {
+		requestsPerSecond = config.RequestsPerSecond.ValueFloat64()
+	}
+
+	client := rtms.NewClient(rtms.Config{
+		AuthToken:         authToken,
+		CloudTempleID:     cloudTempleID,
+		Endpoint:          stringOrEnv(config.Endpoint, "RTMS_ENDPOINT", rtms.DefaultEndpoint),
+		MaxRetries:        int(maxRetries),
+		RetryMaxWait:      time.Duration(retryMaxWait) * time.Second,
+		RequestsPerSecond: requestsPerSecond,
+	})
+	data := &providerData{client: client}
+	if config.Defaults != nil {
+		data.defaults = *config.Defaults
+	}
+	resp.DataSourceData = data
+	resp.ResourceData = data
+}
+
+func (p *rtmsProvider) DataSources(_ context.Context) []func() datasource.DataSource {
+	return []func() datasource.DataSource{
+		newApplianceDataSource,
+		newPluginDataSource,
+		newTemplateDataSource,
+		newTypologyDataSource,
+		newTeamDataSource,
+		newCheckPeriodDataSource,
+		newTimePeriodDataSource,
+		newHostDataSource,
+		newHostsDataSource,
+		newMonitoringServicesDataSource,
+	}
+}
+
+func (p *rtmsProvider) Resources(_ context.Context) []func() resource.Resource {
+	return []func() resource.Resource{
+		newHostResource,
+		newMonitoringServiceResource,
+	}
+}
+
+// stringOrEnv returns the configured value of v, falling back to the env
+// environment variable and then to def.
+func stringOrEnv(v types.String, env, def string) string {
+	if !v.IsNull() && !v.IsUnknown() {
+		return v.ValueString()
+	}
+	if s := os.Getenv(env); s != "" {
+		return s
+	}
+	return def
+}
+
+// providerDataFrom returns the data built by the provider's Configure
+// method. It is nil until the provider has been configured.
+func providerDataFrom(data any, diags *diag.Diagnostics) *providerData {
+	if data == nil {
+		return nil
+	}
+	pd, ok := data.(*providerData)
+	if !ok {
+		diags.AddError("Unexpected provider data", fmt.Sprintf("Expected *providerData, got %T.", data))
+	}
+	return pd
+}
+
+// clientFrom returns the client built by the provider's Configure method.
+// It is nil until the provider has been configured.
+func clientFrom(data any, diags *diag.Diagnostics) *rtms.Client {
+	pd := providerDataFrom(data, diags)
+	if pd == nil {
+		return nil
+	}
+	return pd.client
+}
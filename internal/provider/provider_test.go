@@ -0,0 +1,279 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"testing"
+
+	"github.com/Bithault/terraform-provider-rtms/rtms"
+	"github.com/Bithault/terraform-provider-rtms/rtms/rtmstest"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+// Acceptance tests run when TF_ACC is set. They target the tenant given by
+// RTMS_ENDPOINT, RTMS_AUTH_TOKEN and RTMS_CLOUD_TEMPLE_ID, or an in-process
+// rtmstest.Server when RTMS_ENDPOINT is not set. The catalog entries the
+// test configurations refer to can be changed with RTMS_TEST_APPLIANCE,
+// RTMS_TEST_TEMPLATE and RTMS_TEST_PLUGIN; they default to the fixtures of
+// the fake server.
+
+// testAccNamePrefix starts the name of every object created by the
+// acceptance tests.
+const testAccNamePrefix = "tf-acc-test"
+
+var testAccProtoV6ProviderFactories = map[string]func() (tfprotov6.ProviderServer, error){
+	"rtms": providerserver.NewProtocol6WithError(New("test")()),
+}
+
+// testAccServer is the fake API the acceptance tests run against, or nil
+// when they target RTMS_ENDPOINT.
+var testAccServer *rtmstest.Server
+
+func TestMain(m *testing.M) {
+	if os.Getenv("TF_ACC") != "" && os.Getenv("RTMS_ENDPOINT") == "" {
+		testAccServer = rtmstest.NewServer()
+		os.Setenv("RTMS_ENDPOINT", testAccServer.URL)
+		os.Setenv("RTMS_AUTH_TOKEN", testAccServer.AuthToken)
+		os.Setenv("RTMS_CLOUD_TEMPLE_ID", testAccServer.CloudTempleID)
+	}
+
+	// Runs the sweepers when -sweep is set, the tests otherwise.
+	resource.TestMain(closeAfterRun{m})
+}
+
+// closeAfterRun closes testAccServer once the tests have run, as
+// resource.TestMain exits right after. Sweepers have nothing to remove from
+// the fake server, which is left to the end of the process when they run.
+type closeAfterRun struct {
+	m *testing.M
+}
+
+func (c closeAfterRun) Run() int {
+	code := c.m.Run()
+	if testAccServer != nil {
+		testAccServer.Close()
+	}
+	return code
+}
+
+// testUpgradeState upgrades state, the JSON state of r written with schema
+// version, through the provider server as Terraform does, and returns the
+// upgraded state.
+func testUpgradeState(t *testing.T, r fwresource.Resource, version int64, state string) tfsdk.State {
+	t.Helper()
+	ctx := context.Background()
+
+	var metadata fwresource.MetadataResponse
+	r.Metadata(ctx, fwresource.MetadataRequest{ProviderTypeName: "rtms"}, &metadata)
+	var schema fwresource.SchemaResponse
+	r.Schema(ctx, fwresource.SchemaRequest{}, &schema)
+
+	server, err := testAccProtoV6ProviderFactories["rtms"]()
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := server.UpgradeResourceState(ctx, &tfprotov6.UpgradeResourceStateRequest{
+		TypeName: metadata.TypeName,
+		Version:  version,
+		RawState: &tfprotov6.RawState{JSON: []byte(state)},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, d := range resp.Diagnostics {
+		if d.Severity == tfprotov6.DiagnosticSeverityError {
+			t.Fatalf("UpgradeResourceState: %s: %s", d.Summary, d.Detail)
+		}
+	}
+
+	value, err := resp.UpgradedState.Unmarshal(schema.Schema.Type().TerraformType(ctx))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return tfsdk.State{Schema: schema.Schema, Raw: value}
+}
+
+func testAccPreCheck(t *testing.T) {
+	t.Helper()
+	for _, env := range []string{"RTMS_ENDPOINT", "RTMS_AUTH_TOKEN", "RTMS_CLOUD_TEMPLE_ID"} {
+		if os.Getenv(env) == "" {
+			t.Fatalf("%s must be set for acceptance tests", env)
+		}
+	}
+}
+
+// testAccClient returns a client for the API the acceptance tests run
+// against, to check or change objects behind Terraform's back.
+func testAccClient() *rtms.Client {
+	return rtms.NewClient(rtms.Config{
+		AuthToken:     os.Getenv("RTMS_AUTH_TOKEN"),
+		CloudTempleID: os.Getenv("RTMS_CLOUD_TEMPLE_ID"),
+		Endpoint:      os.Getenv("RTMS_ENDPOINT"),
+	})
+}
+
+// testAccResourceID returns the numeric id of the resource at address in the
+// state.
+func testAccResourceID(s *terraform.State, address string) (int, error) {
+	rs, ok := s.RootModule().Resources[address]
+	if !ok {
+		return 0, fmt.Errorf("%s not found in state", address)
+	}
+	return strconv.Atoi(rs.Primary.ID)
+}
+
+// testAccCatalogConfig declares the data sources test configurations use
+// for the appliance, template and plugin of the objects they create.
+func testAccCatalogConfig() string {
+	return fmt.Sprintf(`
+data "rtms_appliance" "test" {
+  name = %q
+}
+
+data "rtms_template" "test" {
+  name = %q
+}
+
+data "rtms_plugin" "test" {
+  name = %q
+}
+`, testAccApplianceName(), testAccTemplateName(), envOr("RTMS_TEST_PLUGIN", "check_ping"))
+}
+
+func testAccApplianceName() string {
+	return envOr("RTMS_TEST_APPLIANCE", "appliance-1")
+}
+
+func testAccTemplateName() string {
+	return envOr("RTMS_TEST_TEMPLATE", "Linux")
+}
+
+func envOr(env, def string) string {
+	if v := os.Getenv(env); v != "" {
+		return v
+	}
+	return def
+}
+
+// testAccCatalogIDs returns the ids of the appliance and template declared by
+// testAccCatalogConfig, for configurations that cannot use data sources,
+// such as the provider block.
+func testAccCatalogIDs(t *testing.T) (appliance, template int64) {
+	t.Helper()
+	if os.Getenv(resource.EnvTfAcc) == "" {
+		t.Skipf("Acceptance tests skipped unless env %q set", resource.EnvTfAcc)
+	}
+	testAccPreCheck(t)
+
+	ctx := context.Background()
+	client := testAccClient()
+	appliances, err := client.ListAppliances(ctx)
+	if err != nil {
+		t.Fatalf("listing appliances: %s", err)
+	}
+	a, err := findByIDOrName(types.Int64Null(), types.StringValue(testAccApplianceName()),
+		"appliance", appliances, func(a rtms.Appliance) (int, string) { return int(a.ID), a.Name })
+	if err != nil {
+		t.Fatal(err)
+	}
+	templates, err := client.ListTemplates(ctx)
+	if err != nil {
+		t.Fatalf("listing templates: %s", err)
+	}
+	tpl, err := findByIDOrName(types.Int64Null(), types.StringValue(testAccTemplateName()),
+		"template", templates, func(t rtms.Template) (int, string) { return int(t.ID), t.Name })
+	if err != nil {
+		t.Fatal(err)
+	}
+	return int64(a.ID), int64(tpl.ID)
+}
+
+func TestAccProviderDefaults(t *testing.T) {
+	appliance, template := testAccCatalogIDs(t)
+	name := acctest.RandomWithPrefix(testAccNamePrefix)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckMonitoringServiceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderDefaultsConfig(appliance, template, name),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectKnownValue("rtms_host.test", tfjsonpath.New("appliance"), knownvalue.Int64Exact(appliance)),
+						plancheck.ExpectKnownValue("rtms_monitoring_service.test", tfjsonpath.New("appliance"), knownvalue.Int64Exact(appliance)),
+						plancheck.ExpectKnownValue("rtms_monitoring_service.test", tfjsonpath.New("template"), knownvalue.Int64Exact(template)),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("rtms_host.test", "appliance", strconv.FormatInt(appliance, 10)),
+					resource.TestCheckResourceAttr("rtms_monitoring_service.test", "appliance", strconv.FormatInt(appliance, 10)),
+					resource.TestCheckResourceAttr("rtms_monitoring_service.test", "template", strconv.FormatInt(template, 10)),
+				),
+			},
+		},
+	})
+}
+
+func TestAccProviderDefaults_missingTemplate(t *testing.T) {
+	name := acctest.RandomWithPrefix(testAccNamePrefix)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCatalogConfig() + fmt.Sprintf(`
+resource "rtms_host" "test" {
+  name    = %q
+  alias   = "Acceptance test"
+  address = "192.0.2.30"
+}
+
+resource "rtms_monitoring_service" "test" {
+  appliance = data.rtms_appliance.test.id
+  host      = rtms_host.test.id
+  name      = "PING"
+}
+`, name),
+				ExpectError: regexp.MustCompile(`Missing template`),
+			},
+		},
+	})
+}
+
+func testAccProviderDefaultsConfig(appliance, template int64, hostName string) string {
+	return fmt.Sprintf(`
+provider "rtms" {
+  defaults {
+    appliance = %d
+    template  = %d
+  }
+}
+
+resource "rtms_host" "test" {
+  name    = %q
+  alias   = "Acceptance test"
+  address = "192.0.2.30"
+}
+
+resource "rtms_monitoring_service" "test" {
+  host = rtms_host.test.id
+  name = "PING"
+}
+`, appliance, template, hostName)
+}
@@ -0,0 +1,164 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/Bithault/terraform-provider-rtms/rtms"
+	"github.com/Bithault/terraform-provider-rtms/rtms/rtmstest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+// The sweepers delete the objects left behind by failed acceptance tests,
+// in the tenant given by RTMS_AUTH_TOKEN and RTMS_CLOUD_TEMPLE_ID, at
+// RTMS_ENDPOINT or the default endpoint:
+//
+//	go test ./internal/provider -sweep=all
+//
+// Services are swept first, hosts with services cannot be deleted.
+func init() {
+	resource.AddTestSweepers("rtms_monitoring_service", &resource.Sweeper{
+		Name: "rtms_monitoring_service",
+		F: func(string) error {
+			client, err := testAccSweeperClient()
+			if err != nil {
+				return err
+			}
+			return sweepMonitoringServices(context.Background(), client)
+		},
+	})
+	resource.AddTestSweepers("rtms_host", &resource.Sweeper{
+		Name:         "rtms_host",
+		Dependencies: []string{"rtms_monitoring_service"},
+		F: func(string) error {
+			client, err := testAccSweeperClient()
+			if err != nil {
+				return err
+			}
+			return sweepHosts(context.Background(), client)
+		},
+	})
+}
+
+func testAccSweeperClient() (*rtms.Client, error) {
+	for _, env := range []string{"RTMS_AUTH_TOKEN", "RTMS_CLOUD_TEMPLE_ID"} {
+		if os.Getenv(env) == "" {
+			return nil, fmt.Errorf("%s must be set to run the sweepers", env)
+		}
+	}
+	return testAccClient(), nil
+}
+
+// isTestName reports whether name was generated by an acceptance test.
+func isTestName(name string) bool {
+	return strings.HasPrefix(name, testAccNamePrefix)
+}
+
+// sweepMonitoringServices deletes the services created by the acceptance
+// tests: those with a test name and those of a test host.
+func sweepMonitoringServices(ctx context.Context, client *rtms.Client) error {
+	services, err := client.ListMonitoringServices(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("listing monitoring services: %w", err)
+	}
+
+	var errs []error
+	for _, service := range services {
+		if !isTestName(service.Name) && (service.Host == nil || !isTestName(service.Host.Name)) {
+			continue
+		}
+		log.Printf("[INFO] Deleting monitoring service %s (%d)", service.Name, service.ID)
+		if err := client.DeleteMonitoringService(ctx, int(service.ID)); err != nil && !rtms.IsNotFound(err) {
+			errs = append(errs, fmt.Errorf("deleting monitoring service %d: %w", service.ID, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// sweepHosts deletes the hosts created by the acceptance tests.
+func sweepHosts(ctx context.Context, client *rtms.Client) error {
+	hosts, err := client.ListHosts(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("listing hosts: %w", err)
+	}
+
+	var errs []error
+	for _, host := range hosts {
+		if !isTestName(host.Name) {
+			continue
+		}
+		log.Printf("[INFO] Deleting host %s (%d)", host.Name, host.ID)
+		if err := client.DeleteHost(ctx, int(host.ID)); err != nil && !rtms.IsNotFound(err) {
+			errs = append(errs, fmt.Errorf("deleting host %d: %w", host.ID, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func TestSweepers(t *testing.T) {
+	server := rtmstest.NewServer()
+	defer server.Close()
+	// Force the sweepers to walk several pages.
+	server.MaxItemsPerPage = 2
+	client := server.Client()
+	ctx := context.Background()
+
+	createHost := func(name string) int {
+		t.Helper()
+		id, err := client.CreateHost(ctx, &rtms.HostInput{
+			Name:    rtms.String(name),
+			Alias:   rtms.String(name),
+			Address: rtms.String("192.0.2.1"),
+		})
+		if err != nil {
+			t.Fatalf("CreateHost: %v", err)
+		}
+		return id
+	}
+	createService := func(host int, name string) {
+		t.Helper()
+		_, err := client.CreateMonitoringService(ctx, &rtms.MonitoringServiceInput{
+			Host: rtms.Int(host),
+			Name: rtms.String(name),
+		})
+		if err != nil {
+			t.Fatalf("CreateMonitoringService: %v", err)
+		}
+	}
+
+	kept := createHost("web-01")
+	createService(kept, "PING")
+	createService(kept, testAccNamePrefix+"-service")
+	for i := 0; i < 3; i++ {
+		leaked := createHost(fmt.Sprintf("%s-%d", testAccNamePrefix, i))
+		createService(leaked, "PING")
+		createService(leaked, "HTTP")
+	}
+
+	if err := sweepMonitoringServices(ctx, client); err != nil {
+		t.Fatalf("sweepMonitoringServices: %v", err)
+	}
+	if err := sweepHosts(ctx, client); err != nil {
+		t.Fatalf("sweepHosts: %v", err)
+	}
+
+	hosts, err := client.ListHosts(ctx, nil)
+	if err != nil {
+		t.Fatalf("ListHosts: %v", err)
+	}
+	if len(hosts) != 1 || hosts[0].Name != "web-01" {
+		t.Errorf("hosts left: %+v, want web-01 only", hosts)
+	}
+	services, err := client.ListMonitoringServices(ctx, nil)
+	if err != nil {
+		t.Fatalf("ListMonitoringServices: %v", err)
+	}
+	if len(services) != 1 || services[0].Name != "PING" || int(services[0].Host.ID) != kept {
+		t.Errorf("services left: %+v, want the PING service of web-01 only", services)
+	}
+}
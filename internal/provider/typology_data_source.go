@@ -0,0 +1,114 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Bithault/terraform-provider-rtms/rtms"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type typologyDataSource struct {
+	client *rtms.Client
+}
+
+type typologyDataSourceModel struct {
+	ID   types.List   `tfsdk:"id"`
+	Name types.String `tfsdk:"name"`
+}
+
+func newTypologyDataSource() datasource.DataSource {
+	return &typologyDataSource{}
+}
+
+func (d *typologyDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_typology"
+}
+
+func (d *typologyDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.ListAttribute{
+				ElementType: types.Int64Type,
+				Optional:    true,
+				Computed:    true,
+				Validators: []validator.List{
+					listvalidator.AtLeastOneOf(path.MatchRoot("name")),
+				},
+			},
+			"name": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "The ticket catalog items of the typology, as a path of item names separated by \"/\"",
+			},
+		},
+	}
+}
+
+func (d *typologyDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	d.client = clientFrom(req.ProviderData, &resp.Diagnostics)
+}
+
+func (d *typologyDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data typologyDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	items, err := d.client.ListTicketCatalogItems(ctx)
+	if err != nil {
+		resp.Diagnostics.Append(diagFromErr("Unable to list ticket catalog items", err)...)
+		return
+	}
+
+	var typology []rtms.TicketCatalogItem
+	if !data.ID.IsNull() {
+		var ids []int64
+		resp.Diagnostics.Append(data.ID.ElementsAs(ctx, &ids, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		for _, id := range ids {
+			item, err := findOne("ticket catalog item", items, func(i rtms.TicketCatalogItem) bool { return int64(i.ID) == id }, fmt.Sprintf("id %d", id))
+			if err != nil {
+				resp.Diagnostics.AddError("Unable to find typology", err.Error())
+				return
+			}
+			typology = append(typology, item)
+		}
+	} else {
+		for _, name := range strings.Split(data.Name.ValueString(), "/") {
+			item, err := findOne("ticket catalog item", items, func(i rtms.TicketCatalogItem) bool { return i.Name == name }, fmt.Sprintf("name %q", name))
+			if err != nil {
+				resp.Diagnostics.AddError("Unable to find typology", err.Error())
+				return
+			}
+			typology = append(typology, item)
+		}
+	}
+
+	ids := make([]rtms.ID, 0, len(typology))
+	names := make([]string, 0, len(typology))
+	for _, item := range typology {
+		ids = append(ids, item.ID)
+		names = append(names, item.Name)
+	}
+
+	name := strings.Join(names, "/")
+	if !data.ID.IsNull() && !data.Name.IsNull() && data.Name.ValueString() != name {
+		resp.Diagnostics.AddError("Unable to find typology",
+			fmt.Sprintf("the ticket catalog items with the given ids are named %q, not %q", name, data.Name.ValueString()))
+		return
+	}
+
+	data.ID = idList(ids)
+	data.Name = types.StringValue(name)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
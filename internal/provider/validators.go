@@ -0,0 +1,97 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+// validRegexp checks that a string is a valid regular expression.
+type validRegexp struct{}
+
+func (v validRegexp) Description(_ context.Context) string {
+	return "value must be a valid regular expression"
+}
+
+func (v validRegexp) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v validRegexp) ValidateString(_ context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	if _, err := regexp.Compile(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid regular expression", err.Error())
+	}
+}
+
+// validCIDR checks that a string is an IPv4 or IPv6 CIDR block.
+type validCIDR struct{}
+
+func (v validCIDR) Description(_ context.Context) string {
+	return "value must be a CIDR block"
+}
+
+func (v validCIDR) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v validCIDR) ValidateString(_ context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	if _, _, err := net.ParseCIDR(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid CIDR block", err.Error())
+	}
+}
+
+// hostnameLabel matches a single label of a DNS name.
+var hostnameLabel = regexp.MustCompile(`^[a-zA-Z0-9]([a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?$`)
+
+// validAddress checks that a string is an IPv4 or IPv6 address or a fully
+// qualified domain name.
+type validAddress struct{}
+
+func (v validAddress) Description(_ context.Context) string {
+	return "value must be an IPv4 or IPv6 address or a fully qualified domain name"
+}
+
+func (v validAddress) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v validAddress) ValidateString(_ context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	address := req.ConfigValue.ValueString()
+	if net.ParseIP(address) == nil && !isFQDN(address) {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid address",
+			fmt.Sprintf("%q is neither an IP address nor a fully qualified domain name.", address))
+	}
+}
+
+// isFQDN reports whether name looks like a resolvable domain name: at least
+// two valid labels, the last one not numeric so that a mistyped IPv4 address
+// is not accepted.
+func isFQDN(name string) bool {
+	name = strings.TrimSuffix(name, ".")
+	if len(name) > 253 {
+		return false
+	}
+	labels := strings.Split(name, ".")
+	if len(labels) < 2 {
+		return false
+	}
+	for _, label := range labels {
+		if !hostnameLabel.MatchString(label) {
+			return false
+		}
+	}
+	return strings.Trim(labels[len(labels)-1], "0123456789") != ""
+}
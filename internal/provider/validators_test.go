@@ -0,0 +1,42 @@
+package provider
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestValidAddress(t *testing.T) {
+	tests := []struct {
+		address string
+		valid   bool
+	}{
+		{"192.0.2.10", true},
+		{"2001:db8::1", true},
+		{"::1", true},
+		{"web-01.example.com", true},
+		{"web-01.example.com.", true},
+		{"web-01", false},
+		{strings.Repeat("a", 64) + ".example.com", false},
+		{strings.Repeat("a", 63) + ".example.com", true},
+		{"-web.example.com", false},
+		{"192.0.2.300", false},
+		{"web 01/example!", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		req := validator.StringRequest{
+			Path:        path.Root("address"),
+			ConfigValue: types.StringValue(tt.address),
+		}
+		var resp validator.StringResponse
+		validAddress{}.ValidateString(context.Background(), req, &resp)
+		if valid := !resp.Diagnostics.HasError(); valid != tt.valid {
+			t.Errorf("validAddress(%q) valid = %t, want %t: %v", tt.address, valid, tt.valid, resp.Diagnostics)
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/Bithault/terraform-provider-rtms/rtms"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// The pointer helpers below return nil for null and unknown values, so that
+// the matching field is left out of the request and false or 0 are still
+// sent when they are set in the configuration.
+
+func stringPointer(v types.String) *string {
+	if v.IsNull() || v.IsUnknown() {
+		return nil
+	}
+	return rtms.String(v.ValueString())
+}
+
+func intPointer(v types.Int64) *int {
+	if v.IsNull() || v.IsUnknown() {
+		return nil
+	}
+	return rtms.Int(int(v.ValueInt64()))
+}
+
+func boolPointer(v types.Bool) *bool {
+	if v.IsNull() || v.IsUnknown() {
+		return nil
+	}
+	return rtms.Bool(v.ValueBool())
+}
+
+func intsPointer(ctx context.Context, v types.Set, diags *diag.Diagnostics) *[]int {
+	if v.IsNull() || v.IsUnknown() {
+		return nil
+	}
+	var values []int64
+	diags.Append(v.ElementsAs(ctx, &values, false)...)
+	ints := make([]int, 0, len(values))
+	for _, value := range values {
+		ints = append(ints, int(value))
+	}
+	return rtms.Ints(ints)
+}
+
+// changed reports whether an attribute has a new known value in the plan.
+func changed[T attr.Value](plan, state T) bool {
+	return !plan.IsUnknown() && !plan.Equal(state)
+}
+
+// idList converts RTMS ids to a list of numbers.
+func idList(ids []rtms.ID) types.List {
+	values := make([]attr.Value, 0, len(ids))
+	for _, id := range ids {
+		values = append(values, types.Int64Value(int64(id)))
+	}
+	return types.ListValueMust(types.Int64Type, values)
+}
+
+// idSet converts RTMS ids to a set of numbers, dropping duplicates.
+func idSet(ids []rtms.ID) types.Set {
+	seen := make(map[rtms.ID]bool, len(ids))
+	values := make([]attr.Value, 0, len(ids))
+	for _, id := range ids {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		values = append(values, types.Int64Value(int64(id)))
+	}
+	return types.SetValueMust(types.Int64Type, values)
+}
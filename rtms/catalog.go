@@ -0,0 +1,34 @@
+package rtms
+
+import "context"
+
+// ListAppliances returns the appliances of the client's tenant.
+func (c *Client) ListAppliances(ctx context.Context) ([]Appliance, error) {
+	return listAll[Appliance](ctx, c, "/appliances", c.tenantQuery())
+}
+
+// ListPlugins returns the check plugins available to the client's tenant.
+func (c *Client) ListPlugins(ctx context.Context) ([]Plugin, error) {
+	return listAll[Plugin](ctx, c, "/plugins", c.tenantQuery())
+}
+
+// ListTemplates returns the service templates of the client's tenant.
+func (c *Client) ListTemplates(ctx context.Context) ([]Template, error) {
+	return listAll[Template](ctx, c, "/templates", c.tenantQuery())
+}
+
+// ListTeams returns the teams of the client's tenant.
+func (c *Client) ListTeams(ctx context.Context) ([]Team, error) {
+	return listAll[Team](ctx, c, "/teams", c.tenantQuery())
+}
+
+// ListTimePeriods returns the time periods of the client's tenant.
+func (c *Client) ListTimePeriods(ctx context.Context) ([]TimePeriod, error) {
+	return listAll[TimePeriod](ctx, c, "/timePeriods", c.tenantQuery())
+}
+
+// ListTicketCatalogItems returns the ticket catalog items of the client's
+// tenant.
+func (c *Client) ListTicketCatalogItems(ctx context.Context) ([]TicketCatalogItem, error) {
+	return listAll[TicketCatalogItem](ctx, c, "/ticketCatalogsItems", c.tenantQuery())
+}
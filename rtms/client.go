@@ -0,0 +1,249 @@
+// Package rtms is a client for the Cloud Temple RTMS v1 API.
+package rtms
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"math"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// DefaultEndpoint is the base URL of the public RTMS v1 API.
+const DefaultEndpoint = "https://rtms-api.cloud-temple.com/v1"
+
+// Config holds the settings used to build a Client.
+type Config struct {
+	// AuthToken is sent as the X-AUTH-TOKEN header on every request.
+	AuthToken string
+	// CloudTempleID identifies the tenant the client operates on.
+	CloudTempleID string
+	// Endpoint is the base URL of the API. Defaults to DefaultEndpoint.
+	Endpoint string
+	// MaxRetries is the number of times a failed request is sent again after
+	// a transient error. Zero disables retries. Requests throttled with a 429
+	// do not count against it: they are retried up to MaxThrottledRetries
+	// times whatever MaxRetries is.
+	MaxRetries int
+	// RetryMaxWait caps the backoff between two attempts, and the delay
+	// asked for by a Retry-After header. Defaults to DefaultRetryMaxWait.
+	RetryMaxWait time.Duration
+	// RequestsPerSecond limits the rate at which requests are sent, shared by
+	// every call made through the client. Zero means no limit.
+	RequestsPerSecond float64
+	// HTTPClient is used to send requests. Defaults to a new http.Client.
+	HTTPClient *http.Client
+}
+
+// Client talks to the RTMS API on behalf of a single tenant.
+type Client struct {
+	endpoint      string
+	authToken     string
+	cloudTempleID string
+	httpClient    *http.Client
+	maxRetries    int
+	retryMaxWait  time.Duration
+	limiter       *rate.Limiter
+}
+
+// NewClient returns a Client configured from cfg.
+func NewClient(cfg Config) *Client {
+	httpClient := cfg.HTTPClient
+	if httpClient == nil {
+		httpClient = &http.Client{}
+	}
+	endpoint := strings.TrimRight(cfg.Endpoint, "/")
+	if endpoint == "" {
+		endpoint = DefaultEndpoint
+	}
+	retryMaxWait := cfg.RetryMaxWait
+	if retryMaxWait <= 0 {
+		retryMaxWait = DefaultRetryMaxWait
+	}
+	limiter := rate.NewLimiter(rate.Inf, 0)
+	if cfg.RequestsPerSecond > 0 {
+		burst := int(math.Ceil(cfg.RequestsPerSecond))
+		limiter = rate.NewLimiter(rate.Limit(cfg.RequestsPerSecond), burst)
+	}
+
+	return &Client{
+		endpoint:      endpoint,
+		authToken:     cfg.AuthToken,
+		cloudTempleID: cfg.CloudTempleID,
+		httpClient:    httpClient,
+		maxRetries:    cfg.MaxRetries,
+		retryMaxWait:  retryMaxWait,
+		limiter:       limiter,
+	}
+}
+
+// CloudTempleID returns the tenant the client operates on.
+func (c *Client) CloudTempleID() string {
+	return c.cloudTempleID
+}
+
+// Endpoint returns the base URL the client sends requests to.
+func (c *Client) Endpoint() string {
+	return c.endpoint
+}
+
+// tenantQuery returns the query string scoping a request to the client's tenant.
+func (c *Client) tenantQuery() url.Values {
+	return url.Values{"cloudTempleId": {c.cloudTempleID}}
+}
+
+// do sends a request to path (relative to the endpoint) and decodes a
+// successful JSON response into out, if out is not nil. Transient failures are
+// retried according to the client's retry settings until ctx is done. Non-2xx
+// responses are returned as *APIError.
+func (c *Client) do(ctx context.Context, method, path string, query url.Values, in, out interface{}) error {
+	u := c.endpoint + path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+
+	var jsonBody []byte
+	if in != nil {
+		var err error
+		jsonBody, err = json.Marshal(in)
+		if err != nil {
+			return err
+		}
+	}
+
+	var retries, throttled int
+	for {
+		resp, err := c.send(ctx, method, u, jsonBody)
+		if wait, ok := c.retryWait(method, resp, err, &retries, &throttled); ok {
+			log.Printf("[WARN] RTMS API %s %s failed (status %d, error: %v), retrying in %s", method, path, resp.statusCode, err, wait)
+			if err := sleep(ctx, wait); err != nil {
+				return err
+			}
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		if resp.statusCode < 200 || resp.statusCode >= 300 {
+			return newAPIError(resp.statusCode, resp.body)
+		}
+
+		if out == nil {
+			return nil
+		}
+
+		if err := json.Unmarshal(resp.body, out); err != nil {
+			return newDecodeError(err, resp.body)
+		}
+		if checked, ok := out.(requiredFields); ok {
+			if field := checked.missingField(); field != "" {
+				return &DecodeError{Field: field, Err: errors.New("missing from response"), Body: resp.body}
+			}
+		}
+
+		return nil
+	}
+}
+
+// retryWait reports whether the attempt that ended with resp or err is
+// retried, and how long to wait before. retries and throttled count the
+// retries already made after transient errors and after 429 responses, which
+// have separate budgets.
+func (c *Client) retryWait(method string, resp response, err error, retries, throttled *int) (time.Duration, bool) {
+	if !shouldRetry(method, resp.statusCode, err) {
+		return 0, false
+	}
+
+	if err == nil && resp.statusCode == http.StatusTooManyRequests {
+		if *throttled >= MaxThrottledRetries {
+			return 0, false
+		}
+		wait := backoff(*throttled, c.retryMaxWait)
+		*throttled++
+		if retryAfter, ok := parseRetryAfter(resp.header.Get("Retry-After")); ok {
+			wait = retryAfter
+		}
+		if wait > c.retryMaxWait {
+			wait = c.retryMaxWait
+		}
+		return wait, true
+	}
+
+	if *retries >= c.maxRetries {
+		return 0, false
+	}
+	wait := backoff(*retries, c.retryMaxWait)
+	*retries++
+	return wait, true
+}
+
+// requiredFields is implemented by response envelopes that need some fields
+// to be present.
+type requiredFields interface {
+	// missingField returns the JSON path of the first required field that
+	// was not in the response, or "" if none is missing.
+	missingField() string
+}
+
+// dataEnvelope is the {"data": ...} wrapper of single-object responses.
+type dataEnvelope[T any] struct {
+	Data *T `json:"data"`
+}
+
+func (e *dataEnvelope[T]) missingField() string {
+	if e.Data == nil {
+		return "data"
+	}
+	return ""
+}
+
+// response is what is kept of an HTTP response once its body has been read.
+type response struct {
+	statusCode int
+	header     http.Header
+	body       []byte
+}
+
+// send waits for the rate limiter, performs a single attempt and reads the
+// response.
+func (c *Client) send(ctx context.Context, method, u string, jsonBody []byte) (response, error) {
+	var body io.Reader
+	if jsonBody != nil {
+		body = bytes.NewReader(jsonBody)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, u, body)
+	if err != nil {
+		return response{}, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-AUTH-TOKEN", c.authToken)
+
+	if err := c.limiter.Wait(ctx); err != nil {
+		return response{}, err
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return response{}, err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return response{statusCode: resp.StatusCode}, fmt.Errorf("API request error. Status Code: %d. Error reading body: %w", resp.StatusCode, err)
+	}
+
+	return response{statusCode: resp.StatusCode, header: resp.Header, body: respBody}, nil
+}
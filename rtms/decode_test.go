@@ -0,0 +1,171 @@
+package rtms
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestParseID(t *testing.T) {
+	tests := []struct {
+		data string
+		want int
+		ok   bool
+	}{
+		{`12`, 12, true},
+		{`"12"`, 12, true},
+		{` 12 `, 12, true},
+		{`12.0`, 12, true},
+		{`1e2`, 100, true},
+		{`12.5`, 0, false},
+		{`"12.5"`, 0, false},
+		{`"abc"`, 0, false},
+		{`""`, 0, false},
+		{`null`, 0, false},
+		{`true`, 0, false},
+	}
+	for _, tt := range tests {
+		got, ok := parseID([]byte(tt.data))
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("parseID(%s) = %d, %t, want %d, %t", tt.data, got, ok, tt.want, tt.ok)
+		}
+
+		var id ID
+		err := id.UnmarshalJSON([]byte(tt.data))
+		if tt.ok && (err != nil || int(id) != tt.want) {
+			t.Errorf("UnmarshalJSON(%s) = %d, %v, want %d", tt.data, id, err, tt.want)
+		}
+		var idErr *idError
+		if !tt.ok && !errors.As(err, &idErr) {
+			t.Errorf("UnmarshalJSON(%s) error = %v, want an idError", tt.data, err)
+		}
+	}
+}
+
+// TestDecodeError checks the error returned for successful responses that
+// do not have the expected shape, and the field it blames.
+func TestDecodeError(t *testing.T) {
+	tests := []struct {
+		name  string
+		body  string
+		call  func(*Client) error
+		field string
+	}{
+		{
+			name: "string id",
+			body: `{"data": {"id": "12", "name": "web-01", "appliance": {"id": "1"}}}`,
+			call: getHost,
+		},
+		{
+			name:  "null id",
+			body:  `{"data": {"id": null, "name": "web-01"}}`,
+			call:  getHost,
+			field: "data.id",
+		},
+		{
+			name:  "non-numeric id",
+			body:  `{"data": {"id": "abc", "name": "web-01"}}`,
+			call:  getHost,
+			field: "data.id",
+		},
+		{
+			name:  "fractional id",
+			body:  `{"data": {"id": 12.5, "name": "web-01"}}`,
+			call:  getHost,
+			field: "data.id",
+		},
+		{
+			name:  "nested null id",
+			body:  `{"data": {"id": 12, "name": "web-01", "appliance": {"id": null}}}`,
+			call:  getHost,
+			field: "data.appliance.id",
+		},
+		{
+			name:  "bad id in a list",
+			body:  `{"data": [{"id": 1}, {"id": "abc"}]}`,
+			call:  listHosts,
+			field: "data[1].id",
+		},
+		{
+			name:  "wrong type",
+			body:  `{"data": {"id": 12, "name": 12}}`,
+			call:  getHost,
+			field: "data.name",
+		},
+		{
+			name:  "missing data envelope",
+			body:  `{"id": 12, "name": "web-01"}`,
+			call:  getHost,
+			field: "data",
+		},
+		{
+			name:  "missing id",
+			body:  `{}`,
+			call:  createHost,
+			field: "hostId",
+		},
+		{
+			name:  "null created id",
+			body:  `{"hostId": null}`,
+			call:  createHost,
+			field: "hostId",
+		},
+		{
+			name:  "non-numeric created id",
+			body:  `{"hostId": "abc"}`,
+			call:  createHost,
+			field: "hostId",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				// The list ends with an empty page.
+				if r.URL.Query().Get("page") == "2" {
+					w.Write([]byte(`{"data": []}`))
+					return
+				}
+				w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			err := tt.call(NewClient(Config{Endpoint: server.URL}))
+			if tt.field == "" {
+				if err != nil {
+					t.Fatalf("got %v, want no error", err)
+				}
+				return
+			}
+			var decodeErr *DecodeError
+			if !errors.As(err, &decodeErr) {
+				t.Fatalf("got %v, want a DecodeError", err)
+			}
+			if decodeErr.Field != tt.field {
+				t.Errorf("Field = %q, want %q", decodeErr.Field, tt.field)
+			}
+			if string(decodeErr.Body) != tt.body {
+				t.Errorf("Body = %s, want %s", decodeErr.Body, tt.body)
+			}
+		})
+	}
+}
+
+func getHost(c *Client) error {
+	host, err := c.GetHost(context.Background(), 12)
+	if err == nil && (host.ID != 12 || host.Appliance == nil || host.Appliance.ID != 1) {
+		return errors.New("host not decoded")
+	}
+	return err
+}
+
+func listHosts(c *Client) error {
+	_, err := c.ListHosts(context.Background(), nil)
+	return err
+}
+
+func createHost(c *Client) error {
+	_, err := c.CreateHost(context.Background(), &HostInput{Name: String("web-01")})
+	return err
+}
@@ -0,0 +1,162 @@
+package rtms
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// ValidationError is the body returned by the API when a request is rejected
+// with a 400.
+type ValidationError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+	Errors  struct {
+		Children map[string]struct {
+			Errors []string `json:"errors"`
+		} `json:"children"`
+	} `json:"errors"`
+}
+
+// APIError is returned for any non-2xx response.
+type APIError struct {
+	StatusCode int
+	Body       []byte
+	// Validation is set when the body is a ValidationError.
+	Validation *ValidationError
+}
+
+func newAPIError(statusCode int, body []byte) *APIError {
+	apiErr := &APIError{StatusCode: statusCode, Body: body}
+
+	var validationError ValidationError
+	if err := json.Unmarshal(body, &validationError); err == nil && validationError.Code == http.StatusBadRequest {
+		apiErr.Validation = &validationError
+	}
+
+	return apiErr
+}
+
+func (e *APIError) Error() string {
+	if e.Validation != nil {
+		var errorMessages []string
+		for field, errorData := range e.Validation.Errors.Children {
+			for _, errorMsg := range errorData.Errors {
+				errorMessages = append(errorMessages, fmt.Sprintf("%s: %s", field, errorMsg))
+			}
+		}
+		return fmt.Sprintf("API Validation Error. Status Code: %d. Message: %s. Errors: %s",
+			e.Validation.Code,
+			e.Validation.Message,
+			strings.Join(errorMessages, "; "))
+	}
+
+	// Si ce n'est pas une erreur de validation, retournons le corps brut
+	return fmt.Sprintf("API request error. Status Code: %d. Response: %s", e.StatusCode, string(e.Body))
+}
+
+// IsNotFound reports whether err is an API error with a 404 status.
+func IsNotFound(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound
+}
+
+// maxErrorBodyLength bounds how much of a response body is quoted in errors.
+const maxErrorBodyLength = 512
+
+// DecodeError is returned when a successful response does not have the
+// expected shape.
+type DecodeError struct {
+	// Field is the JSON path of the offending field, such as
+	// "data.appliance.id".
+	Field string
+	Err   error
+	Body  []byte
+}
+
+func newDecodeError(err error, body []byte) *DecodeError {
+	decodeErr := &DecodeError{Err: err, Body: body}
+
+	var typeErr *json.UnmarshalTypeError
+	var idErr *idError
+	switch {
+	case errors.As(err, &idErr):
+		decodeErr.Err = idErr
+		decodeErr.Field = findBadID(body)
+	case errors.As(err, &typeErr):
+		decodeErr.Field = strings.TrimPrefix(typeErr.Field, ".")
+		decodeErr.Err = fmt.Errorf("cannot decode %s as %s", typeErr.Value, typeErr.Type)
+	}
+
+	return decodeErr
+}
+
+// findBadID returns the path of the first id in body that is null or not a
+// number. encoding/json does not report where an Unmarshaler failed, so the
+// document is walked again to name the field.
+func findBadID(body []byte) string {
+	var doc interface{}
+	if err := json.Unmarshal(body, &doc); err != nil {
+		return ""
+	}
+	return walkBadID(doc, "")
+}
+
+func walkBadID(v interface{}, path string) string {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		for _, k := range keys {
+			fieldPath := k
+			if path != "" {
+				fieldPath = path + "." + k
+			}
+			if k == "id" || k == "hostId" {
+				raw, _ := json.Marshal(v[k])
+				if _, ok := parseID(raw); !ok {
+					return fieldPath
+				}
+				continue
+			}
+			if found := walkBadID(v[k], fieldPath); found != "" {
+				return found
+			}
+		}
+	case []interface{}:
+		for i, item := range v {
+			if found := walkBadID(item, fmt.Sprintf("%s[%d]", path, i)); found != "" {
+				return found
+			}
+		}
+	}
+	return ""
+}
+
+func (e *DecodeError) Error() string {
+	msg := "Unexpected response format"
+	if e.Field != "" {
+		msg += fmt.Sprintf(" for field %q", e.Field)
+	}
+	return fmt.Sprintf("%s: %s. Response: %s", msg, e.Err, TruncateBody(e.Body))
+}
+
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
+
+// TruncateBody returns body as a string, cut to a length suitable for an
+// error message.
+func TruncateBody(body []byte) string {
+	if len(body) <= maxErrorBodyLength {
+		return string(body)
+	}
+	return string(body[:maxErrorBodyLength]) + "... (truncated)"
+}
@@ -0,0 +1,131 @@
+package rtms
+
+import (
+	"context"
+	"strconv"
+)
+
+// Host is a monitored host as returned by GET /hosts/{id}.
+type Host struct {
+	ID         ID         `json:"id"`
+	Name       string     `json:"name"`
+	Alias      string     `json:"alias"`
+	Address    string     `json:"address"`
+	Community  string     `json:"community"`
+	AdminLogin string     `json:"adminLogin"`
+	Type       string     `json:"type"`
+	Appliance  *Appliance `json:"appliance"`
+}
+
+// Host types accepted by RTMS.
+const (
+	HostTypeServer   = "server"
+	HostTypeSwitch   = "switch"
+	HostTypeRouter   = "router"
+	HostTypeFirewall = "firewall"
+	HostTypeStorage  = "storage"
+	HostTypeUPS      = "ups"
+	HostTypePrinter  = "printer"
+	HostTypeOther    = "other"
+)
+
+// HostTypes lists every host type accepted by RTMS.
+func HostTypes() []string {
+	return []string{
+		HostTypeServer,
+		HostTypeSwitch,
+		HostTypeRouter,
+		HostTypeFirewall,
+		HostTypeStorage,
+		HostTypeUPS,
+		HostTypePrinter,
+		HostTypeOther,
+	}
+}
+
+// IsSNMPHostType reports whether hosts of type t are monitored over SNMP, and
+// therefore need a community.
+func IsSNMPHostType(t string) bool {
+	switch t {
+	case HostTypeSwitch, HostTypeRouter, HostTypeFirewall, HostTypeStorage, HostTypeUPS, HostTypePrinter:
+		return true
+	}
+	return false
+}
+
+// HostInput is the body of POST /hosts and PATCH /hosts/{id}. Nil fields are
+// left out of the request.
+type HostInput struct {
+	Name          *string `json:"name,omitempty"`
+	Alias         *string `json:"alias,omitempty"`
+	Address       *string `json:"address,omitempty"`
+	Community     *string `json:"community,omitempty"`
+	AdminLogin    *string `json:"adminLogin,omitempty"`
+	AdminPassword *string `json:"adminPassword,omitempty"`
+	Type          *string `json:"type,omitempty"`
+	Appliance     *int    `json:"appliance,omitempty"`
+}
+
+type createHostResult struct {
+	HostID *ID `json:"hostId"`
+}
+
+func (r *createHostResult) missingField() string {
+	if r.HostID == nil {
+		return "hostId"
+	}
+	return ""
+}
+
+// CreateHost creates a host in the client's tenant and returns its id.
+func (c *Client) CreateHost(ctx context.Context, host *HostInput) (int, error) {
+	var result createHostResult
+	if err := c.do(ctx, "POST", "/hosts", c.tenantQuery(), host, &result); err != nil {
+		return 0, err
+	}
+
+	return int(*result.HostID), nil
+}
+
+// HostListOptions narrows down the hosts returned by ListHosts. Zero fields
+// are not sent.
+type HostListOptions struct {
+	Appliance int
+	Type      string
+}
+
+// ListHosts returns the hosts of the client's tenant, following pagination.
+// opts may be nil.
+func (c *Client) ListHosts(ctx context.Context, opts *HostListOptions) ([]Host, error) {
+	query := c.tenantQuery()
+	if opts != nil {
+		if opts.Appliance != 0 {
+			query.Set("appliance", strconv.Itoa(opts.Appliance))
+		}
+		if opts.Type != "" {
+			query.Set("type", opts.Type)
+		}
+	}
+
+	return listAll[Host](ctx, c, "/hosts", query)
+}
+
+// GetHost fetches a host by id.
+func (c *Client) GetHost(ctx context.Context, id int) (*Host, error) {
+	var result dataEnvelope[Host]
+	if err := c.do(ctx, "GET", "/hosts/"+strconv.Itoa(id), nil, nil, &result); err != nil {
+		return nil, err
+	}
+
+	return result.Data, nil
+}
+
+// PatchHost updates the non-nil fields of host.
+func (c *Client) PatchHost(ctx context.Context, id int, host *HostInput) error {
+	return c.do(ctx, "PATCH", "/hosts/"+strconv.Itoa(id), nil, host, nil)
+}
+
+// DeleteHost deletes a host.
+func (c *Client) DeleteHost(ctx context.Context, id int) error {
+	return c.do(ctx, "DELETE", "/hosts/"+strconv.Itoa(id), nil, nil, nil)
+}
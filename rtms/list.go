@@ -0,0 +1,36 @@
+package rtms
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+)
+
+// listPageSize is the itemsPerPage requested when walking a list endpoint.
+const listPageSize = 500
+
+// listAll walks every page of a list endpoint, starting from query, and
+// returns the concatenated items. The API signals the end of the list with an
+// empty page.
+func listAll[T any](ctx context.Context, c *Client, path string, query url.Values) ([]T, error) {
+	var items []T
+	for page := 1; ; page++ {
+		q := url.Values{}
+		for k, v := range query {
+			q[k] = v
+		}
+		q.Set("page", strconv.Itoa(page))
+		q.Set("itemsPerPage", strconv.Itoa(listPageSize))
+
+		var result struct {
+			Data []T `json:"data"`
+		}
+		if err := c.do(ctx, "GET", path, q, nil, &result); err != nil {
+			return nil, err
+		}
+		if len(result.Data) == 0 {
+			return items, nil
+		}
+		items = append(items, result.Data...)
+	}
+}
@@ -0,0 +1,144 @@
+package rtms
+
+import (
+	"context"
+	"strconv"
+)
+
+// MonitoringService is a service check as returned by
+// GET /monitoringServices/{id}.
+type MonitoringService struct {
+	ID                   ID                  `json:"id"`
+	Name                 string              `json:"name"`
+	Description          string              `json:"description"`
+	Host                 *Ref                `json:"host"`
+	Template             *Template           `json:"template"`
+	Plugin               *Plugin             `json:"plugin"`
+	Appliance            *Appliance          `json:"appliance"`
+	IsMonitored          bool                `json:"isMonitored"`
+	NotificationsEnabled bool                `json:"notificationsEnabled"`
+	NiceName             string              `json:"niceName"`
+	Keywords             string              `json:"keywords"`
+	Help                 string              `json:"help"`
+	Severity             int                 `json:"severity"`
+	OnlyNotifyIfCritical bool                `json:"onlyNotifyIfCritical"`
+	NormalCheckInterval  int                 `json:"normalCheckInterval"`
+	RetryCheckInterval   int                 `json:"retryCheckInterval"`
+	MaxCheckAttempts     int                 `json:"maxCheckAttempts"`
+	TimePeriod           *TimePeriod         `json:"timePeriod"`
+	CheckPeriod          *TimePeriod         `json:"checkPeriod"`
+	TicketCatalogsItems  []TicketCatalogItem `json:"ticketCatalogsItems"`
+	AutoProcessing       bool                `json:"autoProcessing"`
+	ResponsibleTeam      *Team               `json:"responsibleTeam"`
+	State                *ServiceState       `json:"state"`
+}
+
+// ServiceState is the result of the latest check of a service.
+type ServiceState struct {
+	// Status is one of OK, WARNING, CRITICAL or UNKNOWN.
+	Status    string `json:"status"`
+	Output    string `json:"output"`
+	LastCheck string `json:"lastCheck"`
+}
+
+// MonitoringServiceInput is the body of POST /monitoringServices and
+// PATCH /monitoringServices/{id}. Nil fields are left out of the request.
+type MonitoringServiceInput struct {
+	Appliance            *int    `json:"appliance,omitempty"`
+	Host                 *int    `json:"host,omitempty"`
+	Name                 *string `json:"name,omitempty"`
+	Template             *int    `json:"template,omitempty"`
+	Description          *string `json:"description,omitempty"`
+	MaxCheckAttempts     *int    `json:"maxCheckAttempts,omitempty"`
+	Plugin               *int    `json:"plugin,omitempty"`
+	PluginArgs           *string `json:"pluginArgs,omitempty"`
+	IsMonitored          *bool   `json:"isMonitored,omitempty"`
+	NotificationsEnabled *bool   `json:"notificationsEnabled,omitempty"`
+	NiceName             *string `json:"niceName,omitempty"`
+	Keywords             *string `json:"keywords,omitempty"`
+	Help                 *string `json:"help,omitempty"`
+	Severity             *int    `json:"severity,omitempty"`
+	OnlyNotifyIfCritical *bool   `json:"onlyNotifyIfCritical,omitempty"`
+	NormalCheckInterval  *int    `json:"normalCheckInterval,omitempty"`
+	RetryCheckInterval   *int    `json:"retryCheckInterval,omitempty"`
+	TimePeriod           *int    `json:"timePeriod,omitempty"`
+	CheckPeriod          *int    `json:"checkPeriod,omitempty"`
+	TicketCatalogsItems  *[]int  `json:"ticketCatalogsItems,omitempty"`
+	AutoProcessing       *bool   `json:"autoProcessing,omitempty"`
+	ResponsibleTeam      *int    `json:"responsibleTeam,omitempty"`
+}
+
+type createMonitoringServiceResult struct {
+	ID *ID `json:"id"`
+}
+
+func (r *createMonitoringServiceResult) missingField() string {
+	if r.ID == nil {
+		return "id"
+	}
+	return ""
+}
+
+// CreateMonitoringService creates a service in the client's tenant and
+// returns its id.
+func (c *Client) CreateMonitoringService(ctx context.Context, service *MonitoringServiceInput) (int, error) {
+	var result createMonitoringServiceResult
+	if err := c.do(ctx, "POST", "/monitoringServices", c.tenantQuery(), service, &result); err != nil {
+		return 0, err
+	}
+
+	return int(*result.ID), nil
+}
+
+// MonitoringServiceListOptions narrows down the services returned by
+// ListMonitoringServices. Zero fields are not sent.
+type MonitoringServiceListOptions struct {
+	Host            int
+	Template        int
+	Plugin          int
+	ResponsibleTeam int
+	Keyword         string
+}
+
+// ListMonitoringServices returns the services of the client's tenant,
+// following pagination. opts may be nil.
+func (c *Client) ListMonitoringServices(ctx context.Context, opts *MonitoringServiceListOptions) ([]MonitoringService, error) {
+	query := c.tenantQuery()
+	if opts != nil {
+		for key, value := range map[string]int{
+			"host":            opts.Host,
+			"template":        opts.Template,
+			"plugin":          opts.Plugin,
+			"responsibleTeam": opts.ResponsibleTeam,
+		} {
+			if value != 0 {
+				query.Set(key, strconv.Itoa(value))
+			}
+		}
+		if opts.Keyword != "" {
+			query.Set("keywords", opts.Keyword)
+		}
+	}
+
+	return listAll[MonitoringService](ctx, c, "/monitoringServices", query)
+}
+
+// GetMonitoringService fetches a service by id.
+func (c *Client) GetMonitoringService(ctx context.Context, id int) (*MonitoringService, error) {
+	var result dataEnvelope[MonitoringService]
+	if err := c.do(ctx, "GET", "/monitoringServices/"+strconv.Itoa(id), nil, nil, &result); err != nil {
+		return nil, err
+	}
+
+	return result.Data, nil
+}
+
+// PatchMonitoringService updates the non-nil fields of service.
+func (c *Client) PatchMonitoringService(ctx context.Context, id int, service *MonitoringServiceInput) error {
+	return c.do(ctx, "PATCH", "/monitoringServices/"+strconv.Itoa(id), nil, service, nil)
+}
+
+// DeleteMonitoringService deletes a service.
+func (c *Client) DeleteMonitoringService(ctx context.Context, id int) error {
+	return c.do(ctx, "DELETE", "/monitoringServices/"+strconv.Itoa(id), nil, nil, nil)
+}
@@ -0,0 +1,131 @@
+package rtms
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"syscall"
+	"time"
+)
+
+const (
+	// DefaultRetryMaxWait caps the delay between two attempts.
+	DefaultRetryMaxWait = 30 * time.Second
+
+	// MaxThrottledRetries is the number of times a request throttled with a
+	// 429 is sent again, independently of Config.MaxRetries.
+	MaxThrottledRetries = 10
+
+	retryMinWait = time.Second
+)
+
+// isIdempotent reports whether a request with this method can be sent again
+// without risking a duplicate side effect on the server.
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodPatch, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// shouldRetry decides whether an attempt that ended with statusCode or err is worth
+// repeating. A 429 means the server refused the request outright, so it is
+// retried whatever the method. Otherwise non-idempotent requests are only
+// retried when the connection to the server could not be established, so they
+// cannot have been processed.
+func shouldRetry(method string, statusCode int, err error) bool {
+	if err != nil {
+		if neverSent(err) {
+			return true
+		}
+		return isIdempotent(method) && isTransientNetError(err)
+	}
+
+	if statusCode == http.StatusTooManyRequests {
+		return true
+	}
+
+	if !isIdempotent(method) {
+		return false
+	}
+
+	switch statusCode {
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// neverSent reports whether err happened before any byte of the request could
+// reach the server: DNS resolution or TCP connection failures.
+func neverSent(err error) bool {
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return true
+	}
+	var opErr *net.OpError
+	if errors.As(err, &opErr) && opErr.Op == "dial" {
+		return true
+	}
+	return errors.Is(err, syscall.ECONNREFUSED)
+}
+
+func isTransientNetError(err error) bool {
+	if errors.Is(err, syscall.ECONNRESET) || errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+// parseRetryAfter reads a Retry-After header given either in seconds or as
+// an HTTP date.
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		wait := time.Until(date)
+		if wait < 0 {
+			wait = 0
+		}
+		return wait, true
+	}
+	return 0, false
+}
+
+// backoff returns the jittered delay before retry number attempt (starting
+// at 0): a random duration between half and all of min(maxWait, 1s * 2^attempt).
+func backoff(attempt int, maxWait time.Duration) time.Duration {
+	wait := retryMinWait << uint(attempt)
+	if wait <= 0 || wait > maxWait {
+		wait = maxWait
+	}
+	half := wait / 2
+	if half <= 0 {
+		return wait
+	}
+	return half + time.Duration(rand.Int63n(int64(half)+1))
+}
+
+// sleep waits for d, returning early with the context's error if ctx is done
+// first.
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,315 @@
+package rtms
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+)
+
+// timeoutError is a net.Error reporting a timeout.
+type timeoutError struct{}
+
+func (timeoutError) Error() string   { return "i/o timeout" }
+func (timeoutError) Timeout() bool   { return true }
+func (timeoutError) Temporary() bool { return true }
+
+func TestShouldRetry(t *testing.T) {
+	dialErr := &url.Error{Op: "Post", URL: "https://rtms.test", Err: &net.OpError{Op: "dial", Net: "tcp", Err: syscall.ECONNREFUSED}}
+	resetErr := &url.Error{Op: "Post", URL: "https://rtms.test", Err: &net.OpError{Op: "read", Net: "tcp", Err: syscall.ECONNRESET}}
+
+	tests := []struct {
+		name       string
+		method     string
+		statusCode int
+		err        error
+		want       bool
+	}{
+		{"GET 200", http.MethodGet, http.StatusOK, nil, false},
+		{"GET 400", http.MethodGet, http.StatusBadRequest, nil, false},
+		{"GET 500", http.MethodGet, http.StatusInternalServerError, nil, false},
+		{"GET 502", http.MethodGet, http.StatusBadGateway, nil, true},
+		{"GET 503", http.MethodGet, http.StatusServiceUnavailable, nil, true},
+		{"GET 504", http.MethodGet, http.StatusGatewayTimeout, nil, true},
+		{"PATCH 503", http.MethodPatch, http.StatusServiceUnavailable, nil, true},
+		{"DELETE 502", http.MethodDelete, http.StatusBadGateway, nil, true},
+		{"POST 502", http.MethodPost, http.StatusBadGateway, nil, false},
+		{"POST 503", http.MethodPost, http.StatusServiceUnavailable, nil, false},
+		{"POST 504", http.MethodPost, http.StatusGatewayTimeout, nil, false},
+		{"GET 429", http.MethodGet, http.StatusTooManyRequests, nil, true},
+		{"POST 429", http.MethodPost, http.StatusTooManyRequests, nil, true},
+		{"GET connection refused", http.MethodGet, 0, dialErr, true},
+		{"POST connection refused", http.MethodPost, 0, dialErr, true},
+		{"POST DNS failure", http.MethodPost, 0, &net.DNSError{Err: "no such host", Name: "rtms.test"}, true},
+		{"GET connection reset", http.MethodGet, 0, resetErr, true},
+		{"POST connection reset", http.MethodPost, 0, resetErr, false},
+		{"GET unexpected EOF", http.MethodGet, 0, io.ErrUnexpectedEOF, true},
+		{"POST unexpected EOF", http.MethodPost, 0, io.ErrUnexpectedEOF, false},
+		{"GET timeout", http.MethodGet, 0, timeoutError{}, true},
+		{"POST timeout", http.MethodPost, 0, timeoutError{}, false},
+		{"GET other error", http.MethodGet, 0, errors.New("boom"), false},
+		{"GET canceled", http.MethodGet, 0, context.Canceled, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := shouldRetry(tt.method, tt.statusCode, tt.err); got != tt.want {
+				t.Errorf("shouldRetry(%s, %d, %v) = %t, want %t", tt.method, tt.statusCode, tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNeverSent(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"DNS failure", &net.DNSError{Err: "no such host", Name: "rtms.test"}, true},
+		{"dial failure", &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("network is unreachable")}, true},
+		{"wrapped dial failure", &url.Error{Op: "Get", URL: "https://rtms.test", Err: &net.OpError{Op: "dial", Net: "tcp", Err: timeoutError{}}}, true},
+		{"connection refused", fmt.Errorf("sending: %w", syscall.ECONNREFUSED), true},
+		{"read failure", &net.OpError{Op: "read", Net: "tcp", Err: syscall.ECONNRESET}, false},
+		{"EOF", io.EOF, false},
+		{"timeout", timeoutError{}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := neverSent(tt.err); got != tt.want {
+				t.Errorf("neverSent(%v) = %t, want %t", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	tests := []struct {
+		attempt int
+		maxWait time.Duration
+		want    time.Duration // before jitter
+	}{
+		{0, DefaultRetryMaxWait, time.Second},
+		{1, DefaultRetryMaxWait, 2 * time.Second},
+		{3, DefaultRetryMaxWait, 8 * time.Second},
+		{4, DefaultRetryMaxWait, 16 * time.Second},
+		{5, DefaultRetryMaxWait, DefaultRetryMaxWait},
+		{10, DefaultRetryMaxWait, DefaultRetryMaxWait},
+		// The shift overflows.
+		{100, DefaultRetryMaxWait, DefaultRetryMaxWait},
+		{2, 3 * time.Second, 3 * time.Second},
+		{0, 100 * time.Millisecond, 100 * time.Millisecond},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("attempt %d max %s", tt.attempt, tt.maxWait), func(t *testing.T) {
+			for i := 0; i < 100; i++ {
+				got := backoff(tt.attempt, tt.maxWait)
+				if got < tt.want/2 || got > tt.want {
+					t.Fatalf("backoff(%d, %s) = %s, want between %s and %s", tt.attempt, tt.maxWait, got, tt.want/2, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestClientRetriesTransientFailures(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			http.Error(w, "maintenance", http.StatusServiceUnavailable)
+			return
+		}
+		fmt.Fprint(w, `{"name":"ok"}`)
+	}))
+	defer server.Close()
+
+	client := NewClient(Config{Endpoint: server.URL, MaxRetries: 2, RetryMaxWait: 10 * time.Millisecond})
+	var out struct{ Name string }
+	if err := client.do(context.Background(), http.MethodGet, "/hosts", nil, nil, &out); err != nil {
+		t.Fatalf("do: %v", err)
+	}
+	if out.Name != "ok" {
+		t.Errorf("decoded %+v, want the response of the second attempt", out)
+	}
+	if got := calls.Load(); got != 2 {
+		t.Errorf("server got %d requests, want 2", got)
+	}
+}
+
+func TestClientDoesNotRetryPostOn503(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		http.Error(w, "maintenance", http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := NewClient(Config{Endpoint: server.URL, MaxRetries: 2, RetryMaxWait: 10 * time.Millisecond})
+	err := client.do(context.Background(), http.MethodPost, "/hosts", nil, map[string]string{"name": "web-01"}, nil)
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusServiceUnavailable {
+		t.Fatalf("do returned %v, want a 503 APIError", err)
+	}
+	if got := calls.Load(); got != 1 {
+		t.Errorf("server got %d requests, want 1", got)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	tests := []struct {
+		name   string
+		value  string
+		want   time.Duration
+		wantOK bool
+	}{
+		{"empty", "", 0, false},
+		{"seconds", "120", 120 * time.Second, true},
+		{"zero seconds", "0", 0, true},
+		{"negative seconds", "-5", 0, false},
+		{"garbage", "soon", 0, false},
+		{"past date", time.Now().Add(-time.Hour).UTC().Format(http.TimeFormat), 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := parseRetryAfter(tt.value)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("parseRetryAfter(%q) = %s, %t, want %s, %t", tt.value, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+
+	t.Run("future date", func(t *testing.T) {
+		value := time.Now().Add(30 * time.Second).UTC().Format(http.TimeFormat)
+		got, ok := parseRetryAfter(value)
+		// HTTP dates have a one second resolution.
+		if !ok || got < 28*time.Second || got > 30*time.Second {
+			t.Errorf("parseRetryAfter(%q) = %s, %t, want about 30s", value, got, ok)
+		}
+	})
+}
+
+func TestClientHonorsRetryAfter(t *testing.T) {
+	for name, retryAfter := range map[string]func() string{
+		"seconds": func() string { return "2" },
+		// Dates are truncated to the second, so this waits between 2s and 3s.
+		"date": func() string { return time.Now().Add(3 * time.Second).UTC().Format(http.TimeFormat) },
+	} {
+		t.Run(name, func(t *testing.T) {
+			var calls atomic.Int32
+			var first time.Time
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if calls.Add(1) == 1 {
+					first = time.Now()
+					w.Header().Set("Retry-After", retryAfter())
+					http.Error(w, "slow down", http.StatusTooManyRequests)
+					return
+				}
+				if wait := time.Since(first); wait < 1500*time.Millisecond {
+					t.Errorf("retried after %s, before the Retry-After delay", wait)
+				}
+				fmt.Fprint(w, `{"id":1}`)
+			}))
+			defer server.Close()
+
+			// The backoff alone would retry within 1s. A POST shows that a
+			// 429 is retried whatever the method, and MaxRetries 0 that it
+			// does not use the retry budget.
+			client := NewClient(Config{Endpoint: server.URL, RetryMaxWait: 5 * time.Second})
+			if err := client.do(context.Background(), http.MethodPost, "/hosts", nil, map[string]string{"name": "web-01"}, nil); err != nil {
+				t.Fatalf("do: %v", err)
+			}
+			if got := calls.Load(); got != 2 {
+				t.Errorf("server got %d requests, want 2", got)
+			}
+		})
+	}
+}
+
+func TestClientCapsRetryAfter(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			w.Header().Set("Retry-After", "3600")
+			http.Error(w, "slow down", http.StatusTooManyRequests)
+			return
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(Config{Endpoint: server.URL, RetryMaxWait: 50 * time.Millisecond})
+	start := time.Now()
+	if err := client.do(context.Background(), http.MethodGet, "/hosts", nil, nil, nil); err != nil {
+		t.Fatalf("do: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("do took %s, want the Retry-After delay capped at 50ms", elapsed)
+	}
+	if got := calls.Load(); got != 2 {
+		t.Errorf("server got %d requests, want 2", got)
+	}
+}
+
+func TestClientGivesUpWhenThrottled(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.Header().Set("Retry-After", "0")
+		http.Error(w, "slow down", http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	client := NewClient(Config{Endpoint: server.URL, MaxRetries: 2, RetryMaxWait: 10 * time.Millisecond})
+	err := client.do(context.Background(), http.MethodGet, "/hosts", nil, nil, nil)
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusTooManyRequests {
+		t.Fatalf("do returned %v, want a 429 APIError", err)
+	}
+	if got, want := calls.Load(), int32(MaxThrottledRetries+1); got != want {
+		t.Errorf("server got %d requests, want %d", got, want)
+	}
+}
+
+func TestClientRetryAfterStopsWithContext(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "3600")
+		http.Error(w, "slow down", http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	client := NewClient(Config{Endpoint: server.URL, MaxRetries: 1})
+	if err := client.do(ctx, http.MethodGet, "/hosts", nil, nil, nil); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("do returned %v, want context.DeadlineExceeded", err)
+	}
+}
+
+func TestClientRateLimit(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+	}))
+	defer server.Close()
+
+	// A burst of 5 requests, then one every 200ms.
+	client := NewClient(Config{Endpoint: server.URL, RequestsPerSecond: 5})
+	start := time.Now()
+	for i := 0; i < 7; i++ {
+		if err := client.do(context.Background(), http.MethodGet, "/hosts", nil, nil, nil); err != nil {
+			t.Fatalf("do: %v", err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 350*time.Millisecond {
+		t.Errorf("7 requests at 5 per second took %s, want at least 400ms", elapsed)
+	}
+	if got := calls.Load(); got != 7 {
+		t.Errorf("server got %d requests, want 7", got)
+	}
+}
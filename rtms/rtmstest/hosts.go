@@ -0,0 +1,208 @@
+package rtmstest
+
+import (
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/Bithault/terraform-provider-rtms/rtms"
+)
+
+// host is a host as stored by the server.
+type host struct {
+	id            int
+	name          string
+	alias         string
+	address       string
+	community     string
+	adminLogin    string
+	adminPassword string
+	hostType      string
+	appliance     int
+}
+
+// apply copies the non-nil fields of in to h.
+func (h *host) apply(in *rtms.HostInput) {
+	setIfNotNil(&h.name, in.Name)
+	setIfNotNil(&h.alias, in.Alias)
+	setIfNotNil(&h.address, in.Address)
+	setIfNotNil(&h.community, in.Community)
+	setIfNotNil(&h.adminLogin, in.AdminLogin)
+	setIfNotNil(&h.adminPassword, in.AdminPassword)
+	setIfNotNil(&h.hostType, in.Type)
+	setIfNotNil(&h.appliance, in.Appliance)
+}
+
+func setIfNotNil[T any](field *T, value *T) {
+	if value != nil {
+		*field = *value
+	}
+}
+
+// validateHost checks h the way the API does before saving it.
+func (s *Server) validateHost(h *host) validationErrors {
+	errs := validationErrors{}
+	for field, value := range map[string]string{
+		"name":    h.name,
+		"alias":   h.alias,
+		"address": h.address,
+	} {
+		if strings.TrimSpace(value) == "" {
+			errs.add(field, msgBlank)
+		}
+	}
+	if !slices.Contains(rtms.HostTypes(), h.hostType) {
+		errs.add("type", msgChoice)
+	} else if rtms.IsSNMPHostType(h.hostType) && h.community == "" {
+		errs.add("community", msgBlank)
+	}
+	if _, ok := lookup(s.appliances, h.appliance, applianceID); !ok {
+		errs.add("appliance", msgNotFound)
+	}
+	for _, other := range s.hosts {
+		if other.id != h.id && other.name == h.name {
+			errs.add("name", msgUsed)
+		}
+	}
+	return errs
+}
+
+func applianceID(a rtms.Appliance) rtms.ID { return a.ID }
+
+// renderHost returns h as sent by GET /hosts/{id}.
+func (s *Server) renderHost(h *host) rtms.Host {
+	rendered := rtms.Host{
+		ID:         rtms.ID(h.id),
+		Name:       h.name,
+		Alias:      h.alias,
+		Address:    h.address,
+		Community:  h.community,
+		AdminLogin: h.adminLogin,
+		Type:       h.hostType,
+	}
+	if appliance, ok := lookup(s.appliances, h.appliance, applianceID); ok {
+		rendered.Appliance = &appliance
+	}
+	return rendered
+}
+
+func (s *Server) listHosts(w http.ResponseWriter, r *http.Request) {
+	if !s.checkTenant(w, r) {
+		return
+	}
+	query := r.URL.Query()
+	appliance, _ := strconv.Atoi(query.Get("appliance"))
+	hostType := query.Get("type")
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	hosts := []rtms.Host{}
+	for _, id := range sortedIDs(s.hosts) {
+		h := s.hosts[id]
+		if appliance != 0 && h.appliance != appliance || hostType != "" && h.hostType != hostType {
+			continue
+		}
+		hosts = append(hosts, s.renderHost(h))
+	}
+	writePage(s, w, r, hosts)
+}
+
+func (s *Server) createHost(w http.ResponseWriter, r *http.Request) {
+	if !s.checkTenant(w, r) {
+		return
+	}
+	var in rtms.HostInput
+	if !decodeBody(w, r, &in) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	h := &host{hostType: rtms.HostTypeServer}
+	if len(s.appliances) > 0 {
+		h.appliance = int(s.appliances[0].ID)
+	}
+	h.apply(&in)
+	if errs := s.validateHost(h); len(errs) > 0 {
+		errs.write(w)
+		return
+	}
+	h.id = s.nextID()
+	s.hosts[h.id] = h
+	writeJSON(w, http.StatusCreated, map[string]int{"hostId": h.id})
+}
+
+func (s *Server) getHost(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	h, ok := s.hostFromPath(r)
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]rtms.Host{"data": s.renderHost(h)})
+}
+
+func (s *Server) patchHost(w http.ResponseWriter, r *http.Request) {
+	var in rtms.HostInput
+	if !decodeBody(w, r, &in) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	h, ok := s.hostFromPath(r)
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+	updated := *h
+	updated.apply(&in)
+	if errs := s.validateHost(&updated); len(errs) > 0 {
+		errs.write(w)
+		return
+	}
+	*h = updated
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// deleteHost refuses to delete a host that still has services.
+func (s *Server) deleteHost(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	h, ok := s.hostFromPath(r)
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+	for _, service := range s.services {
+		if service.host == h.id {
+			writeError(w, http.StatusConflict, "The host still has monitoring services.")
+			return
+		}
+	}
+	delete(s.hosts, h.id)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) hostFromPath(r *http.Request) (*host, bool) {
+	id, ok := pathID(r)
+	if !ok {
+		return nil, false
+	}
+	h, ok := s.hosts[id]
+	return h, ok
+}
+
+// HostAdminPassword returns the admin password last sent for a host, which
+// the API never returns.
+func (s *Server) HostAdminPassword(id int) (string, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	h, ok := s.hosts[id]
+	if !ok {
+		return "", false
+	}
+	return h.adminPassword, true
+}
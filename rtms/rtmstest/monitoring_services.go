@@ -0,0 +1,333 @@
+package rtmstest
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/Bithault/terraform-provider-rtms/rtms"
+)
+
+// Values given to the arguments a new service is created without.
+const (
+	defaultSeverity            = 3
+	defaultMaxCheckAttempts    = 3
+	defaultNormalCheckInterval = 5
+	defaultRetryCheckInterval  = 1
+)
+
+// service is a monitoring service as stored by the server. Zero references
+// are unset.
+type service struct {
+	id                   int
+	appliance            int
+	host                 int
+	name                 string
+	template             int
+	description          string
+	maxCheckAttempts     int
+	plugin               int
+	pluginArgs           string
+	isMonitored          bool
+	notificationsEnabled bool
+	niceName             string
+	keywords             string
+	help                 string
+	severity             int
+	onlyNotifyIfCritical bool
+	normalCheckInterval  int
+	retryCheckInterval   int
+	timePeriod           int
+	checkPeriod          int
+	ticketCatalogsItems  []int
+	autoProcessing       bool
+	responsibleTeam      int
+}
+
+// apply copies the non-nil fields of in to m.
+func (m *service) apply(in *rtms.MonitoringServiceInput) {
+	setIfNotNil(&m.appliance, in.Appliance)
+	setIfNotNil(&m.host, in.Host)
+	setIfNotNil(&m.name, in.Name)
+	setIfNotNil(&m.template, in.Template)
+	setIfNotNil(&m.description, in.Description)
+	setIfNotNil(&m.maxCheckAttempts, in.MaxCheckAttempts)
+	setIfNotNil(&m.plugin, in.Plugin)
+	setIfNotNil(&m.pluginArgs, in.PluginArgs)
+	setIfNotNil(&m.isMonitored, in.IsMonitored)
+	setIfNotNil(&m.notificationsEnabled, in.NotificationsEnabled)
+	setIfNotNil(&m.niceName, in.NiceName)
+	setIfNotNil(&m.keywords, in.Keywords)
+	setIfNotNil(&m.help, in.Help)
+	setIfNotNil(&m.severity, in.Severity)
+	setIfNotNil(&m.onlyNotifyIfCritical, in.OnlyNotifyIfCritical)
+	setIfNotNil(&m.normalCheckInterval, in.NormalCheckInterval)
+	setIfNotNil(&m.retryCheckInterval, in.RetryCheckInterval)
+	setIfNotNil(&m.timePeriod, in.TimePeriod)
+	setIfNotNil(&m.checkPeriod, in.CheckPeriod)
+	if in.TicketCatalogsItems != nil {
+		m.ticketCatalogsItems = slices.Clone(*in.TicketCatalogsItems)
+	}
+	setIfNotNil(&m.autoProcessing, in.AutoProcessing)
+	setIfNotNil(&m.responsibleTeam, in.ResponsibleTeam)
+}
+
+// validateMonitoringService checks m the way the API does before saving it.
+func (s *Server) validateMonitoringService(m *service) validationErrors {
+	errs := validationErrors{}
+	if strings.TrimSpace(m.name) == "" {
+		errs.add("name", msgBlank)
+	}
+	if _, ok := s.hosts[m.host]; !ok {
+		errs.add("host", msgNotFound)
+	}
+	for _, ref := range []struct {
+		field string
+		id    int
+		found bool
+	}{
+		{"appliance", m.appliance, hasID(s.appliances, m.appliance, applianceID)},
+		{"template", m.template, hasID(s.templates, m.template, templateID)},
+		{"plugin", m.plugin, hasID(s.plugins, m.plugin, pluginID)},
+		{"timePeriod", m.timePeriod, hasID(s.timePeriods, m.timePeriod, timePeriodID)},
+		{"checkPeriod", m.checkPeriod, hasID(s.timePeriods, m.checkPeriod, timePeriodID)},
+		{"responsibleTeam", m.responsibleTeam, hasID(s.teams, m.responsibleTeam, teamID)},
+	} {
+		if ref.id != 0 && !ref.found {
+			errs.add(ref.field, msgNotFound)
+		}
+	}
+	for _, item := range m.ticketCatalogsItems {
+		if !hasID(s.ticketCatalogItems, item, ticketCatalogItemID) {
+			errs.add("ticketCatalogsItems", msgNotFound)
+			break
+		}
+	}
+	for field, value := range map[string]int{
+		"maxCheckAttempts":    m.maxCheckAttempts,
+		"normalCheckInterval": m.normalCheckInterval,
+		"retryCheckInterval":  m.retryCheckInterval,
+	} {
+		if value < 1 {
+			errs.add(field, fmt.Sprintf(msgTooLow, 1))
+		}
+	}
+	for _, other := range s.services {
+		if other.id != m.id && other.host == m.host && other.name == m.name {
+			errs.add("name", msgUsed)
+		}
+	}
+	return errs
+}
+
+func hasID[T any](items []T, id int, idOf func(T) rtms.ID) bool {
+	_, ok := lookup(items, id, idOf)
+	return ok
+}
+
+func templateID(t rtms.Template) rtms.ID { return t.ID }
+
+func pluginID(p rtms.Plugin) rtms.ID { return p.ID }
+
+func teamID(t rtms.Team) rtms.ID { return t.ID }
+
+func timePeriodID(t rtms.TimePeriod) rtms.ID { return t.ID }
+
+func ticketCatalogItemID(t rtms.TicketCatalogItem) rtms.ID { return t.ID }
+
+// renderMonitoringService returns m as sent by GET /monitoringServices/{id}.
+func (s *Server) renderMonitoringService(m *service) rtms.MonitoringService {
+	rendered := rtms.MonitoringService{
+		ID:                   rtms.ID(m.id),
+		Name:                 m.name,
+		Description:          m.description,
+		IsMonitored:          m.isMonitored,
+		NotificationsEnabled: m.notificationsEnabled,
+		NiceName:             m.niceName,
+		Keywords:             m.keywords,
+		Help:                 m.help,
+		Severity:             m.severity,
+		OnlyNotifyIfCritical: m.onlyNotifyIfCritical,
+		NormalCheckInterval:  m.normalCheckInterval,
+		RetryCheckInterval:   m.retryCheckInterval,
+		MaxCheckAttempts:     m.maxCheckAttempts,
+		TicketCatalogsItems:  []rtms.TicketCatalogItem{},
+		AutoProcessing:       m.autoProcessing,
+	}
+	if h, ok := s.hosts[m.host]; ok {
+		rendered.Host = &rtms.Ref{ID: rtms.ID(h.id), Name: h.name}
+	}
+	if appliance, ok := lookup(s.appliances, m.appliance, applianceID); ok {
+		rendered.Appliance = &appliance
+	}
+	if template, ok := lookup(s.templates, m.template, templateID); ok {
+		rendered.Template = &template
+	}
+	if plugin, ok := lookup(s.plugins, m.plugin, pluginID); ok {
+		rendered.Plugin = &plugin
+	}
+	if timePeriod, ok := lookup(s.timePeriods, m.timePeriod, timePeriodID); ok {
+		rendered.TimePeriod = &timePeriod
+	}
+	if checkPeriod, ok := lookup(s.timePeriods, m.checkPeriod, timePeriodID); ok {
+		rendered.CheckPeriod = &checkPeriod
+	}
+	if team, ok := lookup(s.teams, m.responsibleTeam, teamID); ok {
+		rendered.ResponsibleTeam = &team
+	}
+	for _, id := range m.ticketCatalogsItems {
+		if item, ok := lookup(s.ticketCatalogItems, id, ticketCatalogItemID); ok {
+			rendered.TicketCatalogsItems = append(rendered.TicketCatalogsItems, item)
+		}
+	}
+	if m.isMonitored {
+		rendered.State = &rtms.ServiceState{Status: "UNKNOWN", Output: "Pending first check"}
+	}
+	return rendered
+}
+
+func (s *Server) listMonitoringServices(w http.ResponseWriter, r *http.Request) {
+	if !s.checkTenant(w, r) {
+		return
+	}
+	query := r.URL.Query()
+	filters := map[string]int{}
+	for _, key := range []string{"host", "template", "plugin", "responsibleTeam"} {
+		filters[key], _ = strconv.Atoi(query.Get(key))
+	}
+	keyword := query.Get("keywords")
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	services := []rtms.MonitoringService{}
+	for _, id := range sortedIDs(s.services) {
+		m := s.services[id]
+		if !matchesFilter(filters["host"], m.host) ||
+			!matchesFilter(filters["template"], m.template) ||
+			!matchesFilter(filters["plugin"], m.plugin) ||
+			!matchesFilter(filters["responsibleTeam"], m.responsibleTeam) ||
+			keyword != "" && !hasKeyword(m.keywords, keyword) {
+			continue
+		}
+		services = append(services, s.renderMonitoringService(m))
+	}
+	writePage(s, w, r, services)
+}
+
+func matchesFilter(want, got int) bool {
+	return want == 0 || want == got
+}
+
+// hasKeyword reports whether keyword is one of the comma separated keywords.
+func hasKeyword(keywords, keyword string) bool {
+	for _, k := range strings.Split(keywords, ",") {
+		if strings.TrimSpace(k) == keyword {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Server) createMonitoringService(w http.ResponseWriter, r *http.Request) {
+	if !s.checkTenant(w, r) {
+		return
+	}
+	var in rtms.MonitoringServiceInput
+	if !decodeBody(w, r, &in) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	m := &service{
+		isMonitored:          true,
+		notificationsEnabled: true,
+		severity:             defaultSeverity,
+		maxCheckAttempts:     defaultMaxCheckAttempts,
+		normalCheckInterval:  defaultNormalCheckInterval,
+		retryCheckInterval:   defaultRetryCheckInterval,
+	}
+	// Services run on the appliance of their host unless told otherwise.
+	if in.Host != nil {
+		if h, ok := s.hosts[*in.Host]; ok {
+			m.appliance = h.appliance
+		}
+	}
+	m.apply(&in)
+	if errs := s.validateMonitoringService(m); len(errs) > 0 {
+		errs.write(w)
+		return
+	}
+	m.id = s.nextID()
+	s.services[m.id] = m
+	writeJSON(w, http.StatusCreated, map[string]int{"id": m.id})
+}
+
+func (s *Server) getMonitoringService(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	m, ok := s.monitoringServiceFromPath(r)
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]rtms.MonitoringService{"data": s.renderMonitoringService(m)})
+}
+
+func (s *Server) patchMonitoringService(w http.ResponseWriter, r *http.Request) {
+	var in rtms.MonitoringServiceInput
+	if !decodeBody(w, r, &in) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	m, ok := s.monitoringServiceFromPath(r)
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+	updated := *m
+	updated.apply(&in)
+	if errs := s.validateMonitoringService(&updated); len(errs) > 0 {
+		errs.write(w)
+		return
+	}
+	*m = updated
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) deleteMonitoringService(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	m, ok := s.monitoringServiceFromPath(r)
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+	delete(s.services, m.id)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) monitoringServiceFromPath(r *http.Request) (*service, bool) {
+	id, ok := pathID(r)
+	if !ok {
+		return nil, false
+	}
+	m, ok := s.services[id]
+	return m, ok
+}
+
+// PluginArgs returns the plugin arguments last sent for a service, which the
+// API never returns.
+func (s *Server) PluginArgs(id int) (string, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	m, ok := s.services[id]
+	if !ok {
+		return "", false
+	}
+	return m.pluginArgs, true
+}
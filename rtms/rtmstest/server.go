@@ -0,0 +1,352 @@
+// Package rtmstest provides an in-memory fake of the RTMS v1 API, served by
+// an httptest.Server, so that the client and the provider can be tested
+// without a Cloud Temple tenant.
+package rtmstest
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"sync"
+
+	"github.com/Bithault/terraform-provider-rtms/rtms"
+)
+
+// Credentials accepted by a Server unless changed before the first request.
+const (
+	DefaultAuthToken     = "rtmstest-token"
+	DefaultCloudTempleID = "rtmstest-tenant"
+)
+
+// Server is a fake RTMS API. It implements the hosts and monitoringServices
+// endpoints, including their validation errors, 404s and pagination, and
+// serves the catalog endpoints (appliances, plugins, templates, teams, time
+// periods and ticket catalog items) from fixtures.
+//
+// A new Server has the following catalog:
+//
+//	appliances:          1 "appliance-1" (10.0.0.1), 2 "appliance-2" (10.0.0.2)
+//	plugins:             1 "check_ping", 2 "check_http", 3 "check_old" (deprecated)
+//	templates:           1 "Linux", 2 "Windows"
+//	teams:               1 "Ops", 2 "Network"
+//	timePeriods:         1 "24x7", 2 "Work hours"
+//	ticketCatalogsItems: 1 "Infrastructure", 2 "Servers", 3 "Network"
+//
+// More entries can be added with the Add methods.
+type Server struct {
+	// URL is the endpoint of the fake API, to be used as rtms.Config.Endpoint.
+	URL string
+	// AuthToken is the only X-AUTH-TOKEN accepted.
+	AuthToken string
+	// CloudTempleID is the only tenant that can be listed or created in.
+	CloudTempleID string
+	// MaxItemsPerPage caps the itemsPerPage of list requests, so that tests
+	// can exercise pagination with few objects. Zero means no cap.
+	MaxItemsPerPage int
+
+	server *httptest.Server
+
+	mu                 sync.Mutex
+	lastID             int
+	appliances         []rtms.Appliance
+	plugins            []rtms.Plugin
+	templates          []rtms.Template
+	teams              []rtms.Team
+	timePeriods        []rtms.TimePeriod
+	ticketCatalogItems []rtms.TicketCatalogItem
+	hosts              map[int]*host
+	services           map[int]*service
+}
+
+// NewServer starts a fake RTMS API. It must be closed with Close.
+func NewServer() *Server {
+	s := &Server{
+		AuthToken:     DefaultAuthToken,
+		CloudTempleID: DefaultCloudTempleID,
+		hosts:         make(map[int]*host),
+		services:      make(map[int]*service),
+	}
+
+	s.AddAppliance("appliance-1", "10.0.0.1")
+	s.AddAppliance("appliance-2", "10.0.0.2")
+	s.AddPlugin("check_ping", false)
+	s.AddPlugin("check_http", false)
+	s.AddPlugin("check_old", true)
+	s.AddTemplate("Linux")
+	s.AddTemplate("Windows")
+	s.AddTeam("Ops")
+	s.AddTeam("Network")
+	s.AddTimePeriod("24x7")
+	s.AddTimePeriod("Work hours")
+	s.AddTicketCatalogItem("Infrastructure")
+	s.AddTicketCatalogItem("Servers")
+	s.AddTicketCatalogItem("Network")
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /appliances", listCatalog(s, &s.appliances))
+	mux.HandleFunc("GET /plugins", listCatalog(s, &s.plugins))
+	mux.HandleFunc("GET /templates", listCatalog(s, &s.templates))
+	mux.HandleFunc("GET /teams", listCatalog(s, &s.teams))
+	mux.HandleFunc("GET /timePeriods", listCatalog(s, &s.timePeriods))
+	mux.HandleFunc("GET /ticketCatalogsItems", listCatalog(s, &s.ticketCatalogItems))
+	mux.HandleFunc("GET /hosts", s.listHosts)
+	mux.HandleFunc("POST /hosts", s.createHost)
+	mux.HandleFunc("GET /hosts/{id}", s.getHost)
+	mux.HandleFunc("PATCH /hosts/{id}", s.patchHost)
+	mux.HandleFunc("DELETE /hosts/{id}", s.deleteHost)
+	mux.HandleFunc("GET /monitoringServices", s.listMonitoringServices)
+	mux.HandleFunc("POST /monitoringServices", s.createMonitoringService)
+	mux.HandleFunc("GET /monitoringServices/{id}", s.getMonitoringService)
+	mux.HandleFunc("PATCH /monitoringServices/{id}", s.patchMonitoringService)
+	mux.HandleFunc("DELETE /monitoringServices/{id}", s.deleteMonitoringService)
+
+	s.server = httptest.NewServer(http.StripPrefix("/v1", s.authenticate(mux)))
+	s.URL = s.server.URL + "/v1"
+	return s
+}
+
+// Close shuts the server down.
+func (s *Server) Close() {
+	s.server.Close()
+}
+
+// Config returns a client configuration pointing at the server, with retries
+// and rate limiting disabled.
+func (s *Server) Config() rtms.Config {
+	return rtms.Config{
+		AuthToken:     s.AuthToken,
+		CloudTempleID: s.CloudTempleID,
+		Endpoint:      s.URL,
+	}
+}
+
+// Client returns a client for the server.
+func (s *Server) Client() *rtms.Client {
+	return rtms.NewClient(s.Config())
+}
+
+func (s *Server) nextID() int {
+	s.lastID++
+	return s.lastID
+}
+
+// AddAppliance adds an appliance to the catalog and returns it.
+func (s *Server) AddAppliance(name, address string) rtms.Appliance {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	appliance := rtms.Appliance{ID: rtms.ID(len(s.appliances) + 1), Name: name, Alias: name, Address: address}
+	s.appliances = append(s.appliances, appliance)
+	return appliance
+}
+
+// AddPlugin adds a check plugin to the catalog and returns it.
+func (s *Server) AddPlugin(name string, deprecated bool) rtms.Plugin {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	plugin := rtms.Plugin{ID: rtms.ID(len(s.plugins) + 1), Name: name, IsDeprecated: deprecated}
+	s.plugins = append(s.plugins, plugin)
+	return plugin
+}
+
+// AddTemplate adds a service template to the catalog and returns it.
+func (s *Server) AddTemplate(name string) rtms.Template {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	template := rtms.Template{ID: rtms.ID(len(s.templates) + 1), Name: name}
+	s.templates = append(s.templates, template)
+	return template
+}
+
+// AddTeam adds a team to the catalog and returns it.
+func (s *Server) AddTeam(name string) rtms.Team {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	team := rtms.Team{ID: rtms.ID(len(s.teams) + 1), Name: name}
+	s.teams = append(s.teams, team)
+	return team
+}
+
+// AddTimePeriod adds a time period to the catalog and returns it.
+func (s *Server) AddTimePeriod(name string) rtms.TimePeriod {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	timePeriod := rtms.TimePeriod{ID: rtms.ID(len(s.timePeriods) + 1), Name: name}
+	s.timePeriods = append(s.timePeriods, timePeriod)
+	return timePeriod
+}
+
+// AddTicketCatalogItem adds an item to the ticket catalog and returns it.
+func (s *Server) AddTicketCatalogItem(name string) rtms.TicketCatalogItem {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	item := rtms.TicketCatalogItem{ID: rtms.ID(len(s.ticketCatalogItems) + 1), Name: name}
+	s.ticketCatalogItems = append(s.ticketCatalogItems, item)
+	return item
+}
+
+// authenticate rejects requests without the expected X-AUTH-TOKEN.
+func (s *Server) authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-AUTH-TOKEN") != s.AuthToken {
+			writeError(w, http.StatusUnauthorized, "Invalid credentials.")
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// checkTenant rejects list and create requests that are not scoped to the
+// server's tenant.
+func (s *Server) checkTenant(w http.ResponseWriter, r *http.Request) bool {
+	switch r.URL.Query().Get("cloudTempleId") {
+	case s.CloudTempleID:
+		return true
+	case "":
+		writeBadRequest(w, "The cloudTempleId parameter is required.")
+	default:
+		writeError(w, http.StatusForbidden, "Access Denied.")
+	}
+	return false
+}
+
+// errorBody is the body of non-validation errors.
+type errorBody struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, errorBody{Code: status, Message: message})
+}
+
+func writeNotFound(w http.ResponseWriter) {
+	writeError(w, http.StatusNotFound, "Not Found")
+}
+
+// validationErrors collects the errors of a request body, by API field.
+type validationErrors map[string][]string
+
+func (e validationErrors) add(field, message string) {
+	e[field] = append(e[field], message)
+}
+
+// write sends the errors in the ValidationError shape.
+func (e validationErrors) write(w http.ResponseWriter) {
+	var body rtms.ValidationError
+	body.Code = http.StatusBadRequest
+	body.Message = "Validation Failed"
+	body.Errors.Children = make(map[string]struct {
+		Errors []string `json:"errors"`
+	}, len(e))
+	for field, messages := range e {
+		child := body.Errors.Children[field]
+		child.Errors = messages
+		body.Errors.Children[field] = child
+	}
+	writeJSON(w, http.StatusBadRequest, body)
+}
+
+// Messages used in validation errors, as worded by the API.
+const (
+	msgBlank    = "This value should not be blank."
+	msgChoice   = "The value you selected is not a valid choice."
+	msgNotFound = "This value is not valid."
+	msgUsed     = "This value is already used."
+	msgTooLow   = "This value should be greater than or equal to %d."
+)
+
+// decodeBody decodes a JSON request body into v. Unknown fields are refused,
+// so that a typo in the client is caught by the tests.
+func decodeBody(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(v); err != nil {
+		writeBadRequest(w, "Invalid request body: "+err.Error())
+		return false
+	}
+	return true
+}
+
+// writeBadRequest sends a ValidationError without field errors.
+func writeBadRequest(w http.ResponseWriter, message string) {
+	var body rtms.ValidationError
+	body.Code = http.StatusBadRequest
+	body.Message = message
+	writeJSON(w, http.StatusBadRequest, body)
+}
+
+// pathID returns the {id} of the request, or false if it is not a number.
+func pathID(r *http.Request) (int, bool) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	return id, err == nil
+}
+
+// page returns the items of the page requested by the page and itemsPerPage
+// query parameters. Pages past the end are empty.
+func page[T any](s *Server, r *http.Request, items []T) []T {
+	query := r.URL.Query()
+	number, err := strconv.Atoi(query.Get("page"))
+	if err != nil || number < 1 {
+		number = 1
+	}
+	size, err := strconv.Atoi(query.Get("itemsPerPage"))
+	if err != nil || size < 1 {
+		size = 30
+	}
+	if s.MaxItemsPerPage > 0 && size > s.MaxItemsPerPage {
+		size = s.MaxItemsPerPage
+	}
+
+	start := (number - 1) * size
+	if start >= len(items) {
+		return []T{}
+	}
+	return items[start:min(start+size, len(items))]
+}
+
+// writePage sends a page of items in the {"data": [...]} envelope.
+func writePage[T any](s *Server, w http.ResponseWriter, r *http.Request, items []T) {
+	writeJSON(w, http.StatusOK, struct {
+		Data []T `json:"data"`
+	}{page(s, r, items)})
+}
+
+func listCatalog[T any](s *Server, items *[]T) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !s.checkTenant(w, r) {
+			return
+		}
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		writePage(s, w, r, slices.Clone(*items))
+	}
+}
+
+// sortedIDs returns the keys of m in increasing order.
+func sortedIDs[T any](m map[int]T) []int {
+	ids := make([]int, 0, len(m))
+	for id := range m {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+	return ids
+}
+
+// lookup returns the catalog entry with the given id.
+func lookup[T any](items []T, id int, idOf func(T) rtms.ID) (T, bool) {
+	for _, item := range items {
+		if int(idOf(item)) == id {
+			return item, true
+		}
+	}
+	var zero T
+	return zero, false
+}
@@ -0,0 +1,241 @@
+package rtmstest_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/Bithault/terraform-provider-rtms/rtms"
+	"github.com/Bithault/terraform-provider-rtms/rtms/rtmstest"
+)
+
+func TestHostLifecycle(t *testing.T) {
+	server := rtmstest.NewServer()
+	defer server.Close()
+	client := server.Client()
+	ctx := context.Background()
+
+	id, err := client.CreateHost(ctx, &rtms.HostInput{
+		Name:          rtms.String("web-01"),
+		Alias:         rtms.String("Web 01"),
+		Address:       rtms.String("192.0.2.10"),
+		AdminPassword: rtms.String("secret"),
+	})
+	if err != nil {
+		t.Fatalf("CreateHost: %v", err)
+	}
+
+	host, err := client.GetHost(ctx, id)
+	if err != nil {
+		t.Fatalf("GetHost: %v", err)
+	}
+	if host.Name != "web-01" || host.Type != rtms.HostTypeServer || host.Appliance == nil || host.Appliance.ID != 1 {
+		t.Errorf("GetHost = %+v, want web-01 of type server on appliance 1", host)
+	}
+	if password, _ := server.HostAdminPassword(id); password != "secret" {
+		t.Errorf("admin password = %q, want %q", password, "secret")
+	}
+
+	if err := client.PatchHost(ctx, id, &rtms.HostInput{Alias: rtms.String("Web")}); err != nil {
+		t.Fatalf("PatchHost: %v", err)
+	}
+	host, err = client.GetHost(ctx, id)
+	if err != nil {
+		t.Fatalf("GetHost: %v", err)
+	}
+	if host.Alias != "Web" || host.Address != "192.0.2.10" {
+		t.Errorf("GetHost after patch = %+v, want alias Web and address unchanged", host)
+	}
+
+	if err := client.DeleteHost(ctx, id); err != nil {
+		t.Fatalf("DeleteHost: %v", err)
+	}
+	if _, err := client.GetHost(ctx, id); !rtms.IsNotFound(err) {
+		t.Errorf("GetHost after delete: got %v, want a 404", err)
+	}
+	if err := client.DeleteHost(ctx, id); !rtms.IsNotFound(err) {
+		t.Errorf("DeleteHost after delete: got %v, want a 404", err)
+	}
+}
+
+func TestValidationError(t *testing.T) {
+	server := rtmstest.NewServer()
+	defer server.Close()
+	client := server.Client()
+	ctx := context.Background()
+
+	_, err := client.CreateHost(ctx, &rtms.HostInput{
+		Name:    rtms.String("switch-01"),
+		Address: rtms.String("192.0.2.20"),
+		Type:    rtms.String(rtms.HostTypeSwitch),
+	})
+	var apiErr *rtms.APIError
+	if !errors.As(err, &apiErr) || apiErr.Validation == nil {
+		t.Fatalf("CreateHost: got %v, want a validation error", err)
+	}
+	for _, field := range []string{"alias", "community"} {
+		if len(apiErr.Validation.Errors.Children[field].Errors) == 0 {
+			t.Errorf("no error for %s in %s", field, apiErr.Body)
+		}
+	}
+
+	hostID, err := client.CreateHost(ctx, &rtms.HostInput{
+		Name:    rtms.String("web-01"),
+		Alias:   rtms.String("Web 01"),
+		Address: rtms.String("192.0.2.10"),
+	})
+	if err != nil {
+		t.Fatalf("CreateHost: %v", err)
+	}
+	_, err = client.CreateMonitoringService(ctx, &rtms.MonitoringServiceInput{
+		Host:             rtms.Int(hostID),
+		Name:             rtms.String("ping"),
+		MaxCheckAttempts: rtms.Int(0),
+		Plugin:           rtms.Int(42),
+	})
+	if !errors.As(err, &apiErr) || apiErr.Validation == nil {
+		t.Fatalf("CreateMonitoringService: got %v, want a validation error", err)
+	}
+	for _, field := range []string{"maxCheckAttempts", "plugin"} {
+		if len(apiErr.Validation.Errors.Children[field].Errors) == 0 {
+			t.Errorf("no error for %s in %s", field, apiErr.Body)
+		}
+	}
+}
+
+func TestMonitoringServiceLifecycle(t *testing.T) {
+	server := rtmstest.NewServer()
+	defer server.Close()
+	client := server.Client()
+	ctx := context.Background()
+
+	hostID, err := client.CreateHost(ctx, &rtms.HostInput{
+		Name:      rtms.String("web-01"),
+		Alias:     rtms.String("Web 01"),
+		Address:   rtms.String("192.0.2.10"),
+		Appliance: rtms.Int(2),
+	})
+	if err != nil {
+		t.Fatalf("CreateHost: %v", err)
+	}
+
+	id, err := client.CreateMonitoringService(ctx, &rtms.MonitoringServiceInput{
+		Host:                rtms.Int(hostID),
+		Name:                rtms.String("ping"),
+		Template:            rtms.Int(1),
+		Plugin:              rtms.Int(1),
+		PluginArgs:          rtms.String("-w 100,20% -c 500,60%"),
+		Keywords:            rtms.String("network, icmp"),
+		TicketCatalogsItems: rtms.Ints([]int{1, 2}),
+	})
+	if err != nil {
+		t.Fatalf("CreateMonitoringService: %v", err)
+	}
+
+	service, err := client.GetMonitoringService(ctx, id)
+	if err != nil {
+		t.Fatalf("GetMonitoringService: %v", err)
+	}
+	if service.Host == nil || int(service.Host.ID) != hostID || service.Host.Name != "web-01" {
+		t.Errorf("host = %+v, want web-01", service.Host)
+	}
+	if service.Appliance == nil || service.Appliance.ID != 2 {
+		t.Errorf("appliance = %+v, want the appliance of the host", service.Appliance)
+	}
+	if !service.IsMonitored || service.MaxCheckAttempts == 0 || len(service.TicketCatalogsItems) != 2 {
+		t.Errorf("GetMonitoringService = %+v, want defaults and 2 ticket catalog items", service)
+	}
+	if args, _ := server.PluginArgs(id); args != "-w 100,20% -c 500,60%" {
+		t.Errorf("plugin args = %q", args)
+	}
+
+	if err := client.PatchMonitoringService(ctx, id, &rtms.MonitoringServiceInput{IsMonitored: rtms.Bool(false)}); err != nil {
+		t.Fatalf("PatchMonitoringService: %v", err)
+	}
+	services, err := client.ListMonitoringServices(ctx, &rtms.MonitoringServiceListOptions{Host: hostID, Keyword: "icmp"})
+	if err != nil {
+		t.Fatalf("ListMonitoringServices: %v", err)
+	}
+	if len(services) != 1 || services[0].IsMonitored {
+		t.Errorf("ListMonitoringServices = %+v, want the unmonitored ping service", services)
+	}
+
+	err = client.DeleteHost(ctx, hostID)
+	var apiErr *rtms.APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusConflict {
+		t.Errorf("DeleteHost with a service: got %v, want a 409", err)
+	}
+
+	if err := client.DeleteMonitoringService(ctx, id); err != nil {
+		t.Fatalf("DeleteMonitoringService: %v", err)
+	}
+	if _, err := client.GetMonitoringService(ctx, id); !rtms.IsNotFound(err) {
+		t.Errorf("GetMonitoringService after delete: got %v, want a 404", err)
+	}
+	if err := client.DeleteHost(ctx, hostID); err != nil {
+		t.Errorf("DeleteHost: %v", err)
+	}
+}
+
+func TestPagination(t *testing.T) {
+	server := rtmstest.NewServer()
+	defer server.Close()
+	server.MaxItemsPerPage = 2
+	client := server.Client()
+	ctx := context.Background()
+
+	for i := 0; i < 5; i++ {
+		_, err := client.CreateHost(ctx, &rtms.HostInput{
+			Name:    rtms.String(fmt.Sprintf("host-%d", i)),
+			Alias:   rtms.String(fmt.Sprintf("Host %d", i)),
+			Address: rtms.String(fmt.Sprintf("192.0.2.%d", i+1)),
+		})
+		if err != nil {
+			t.Fatalf("CreateHost: %v", err)
+		}
+	}
+
+	hosts, err := client.ListHosts(ctx, nil)
+	if err != nil {
+		t.Fatalf("ListHosts: %v", err)
+	}
+	if len(hosts) != 5 {
+		t.Fatalf("ListHosts returned %d hosts, want 5", len(hosts))
+	}
+	for i, host := range hosts {
+		if want := fmt.Sprintf("host-%d", i); host.Name != want {
+			t.Errorf("hosts[%d] = %q, want %q", i, host.Name, want)
+		}
+	}
+
+	plugins, err := client.ListPlugins(ctx)
+	if err != nil {
+		t.Fatalf("ListPlugins: %v", err)
+	}
+	if len(plugins) != 3 || !plugins[2].IsDeprecated {
+		t.Errorf("ListPlugins = %+v, want the 3 fixtures", plugins)
+	}
+}
+
+func TestAuthentication(t *testing.T) {
+	server := rtmstest.NewServer()
+	defer server.Close()
+	ctx := context.Background()
+
+	cfg := server.Config()
+	cfg.AuthToken = "wrong"
+	_, err := rtms.NewClient(cfg).ListHosts(ctx, nil)
+	var apiErr *rtms.APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusUnauthorized {
+		t.Errorf("ListHosts with a bad token: got %v, want a 401", err)
+	}
+
+	cfg = server.Config()
+	cfg.CloudTempleID = "other-tenant"
+	_, err = rtms.NewClient(cfg).ListHosts(ctx, nil)
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusForbidden {
+		t.Errorf("ListHosts for another tenant: got %v, want a 403", err)
+	}
+}
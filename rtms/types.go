@@ -0,0 +1,115 @@
+package rtms
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// ID is the identifier of an RTMS object. It decodes from a JSON number or a
+// numeric string, and refuses null so that a missing id is reported instead
+// of silently becoming 0.
+type ID int
+
+// String returns the decimal form of id, as used in Terraform resource ids.
+func (id ID) String() string {
+	return strconv.Itoa(int(id))
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (id *ID) UnmarshalJSON(data []byte) error {
+	n, ok := parseID(data)
+	if !ok {
+		return &idError{value: string(data)}
+	}
+
+	*id = ID(n)
+	return nil
+}
+
+// parseID reads an id given as a JSON number or numeric string.
+func parseID(data []byte) (int, bool) {
+	raw := strings.TrimSpace(string(data))
+	if unquoted, err := strconv.Unquote(raw); err == nil {
+		raw = unquoted
+	}
+
+	if n, err := strconv.Atoi(raw); err == nil {
+		return n, true
+	}
+	f, err := strconv.ParseFloat(raw, 64)
+	if err != nil || f != math.Trunc(f) {
+		return 0, false
+	}
+	return int(f), true
+}
+
+// idError is returned when an id is null or not a number.
+type idError struct {
+	value string
+}
+
+func (e *idError) Error() string {
+	return fmt.Sprintf("cannot decode %s as an id", e.value)
+}
+
+// Ref is the minimal representation of an object embedded in another
+// object's response, such as the host of a service.
+type Ref struct {
+	ID   ID     `json:"id"`
+	Name string `json:"name"`
+}
+
+// Appliance is the RTMS appliance (satellite) that runs the checks.
+type Appliance struct {
+	ID      ID     `json:"id"`
+	Name    string `json:"name"`
+	Alias   string `json:"alias"`
+	Address string `json:"address"`
+}
+
+// Plugin is a check plugin a service can run.
+type Plugin struct {
+	ID           ID     `json:"id"`
+	Name         string `json:"name"`
+	IsDeprecated bool   `json:"isDeprecated"`
+}
+
+// Template is a service template.
+type Template struct {
+	ID   ID     `json:"id"`
+	Name string `json:"name"`
+}
+
+// Team is a team that can be made responsible for a service.
+type Team struct {
+	ID   ID     `json:"id"`
+	Name string `json:"name"`
+}
+
+// TimePeriod is a time period, used both as a service's notification time
+// period and as its check period.
+type TimePeriod struct {
+	ID   ID     `json:"id"`
+	Name string `json:"name"`
+}
+
+// TicketCatalogItem is an entry of the ticket catalog. A service references
+// a path of items, its typology, which classifies the tickets it opens.
+type TicketCatalogItem struct {
+	ID   ID     `json:"id"`
+	Name string `json:"name"`
+}
+
+// String returns a pointer to v.
+func String(v string) *string { return &v }
+
+// Int returns a pointer to v.
+func Int(v int) *int { return &v }
+
+// Bool returns a pointer to v.
+func Bool(v bool) *bool { return &v }
+
+// Ints returns a pointer to v.
+func Ints(v []int) *[]int { return &v }